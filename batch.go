@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/gofrs/uuid"
+)
+
+// catalogBatchWindow is how long the first catalog write waits for others to join its batch.  Terraform
+// applies independent resources concurrently, so their writes arrive within a short time of each other.
+const catalogBatchWindow = 50 * time.Millisecond
+
+// catalogBatcher coalesces catalog upserts and deletes made at about the same time into batch requests.  If
+// a batch request fails, every write it didn't make falls back to its own request, so that errors such as
+// version conflicts are reported against the resource that caused them.
+type catalogBatcher struct {
+	api    *squareapi.Client
+	window time.Duration
+
+	mu      sync.Mutex
+	upserts []*pendingCatalogUpsert
+	deletes []*pendingCatalogDelete
+
+	// upsertCount is how many objects the pending upserts count as towards square's limits, including
+	// nested objects.
+	upsertCount int
+}
+
+type pendingCatalogUpsert struct {
+	object *squareapi.CatalogObject
+	count  int
+	done   chan *catalogUpsertResult
+}
+
+type catalogUpsertResult struct {
+	object *squareapi.CatalogObject
+
+	// alone is set when the write wasn't made as part of a batch, and must be made on its own instead.
+	alone bool
+}
+
+type pendingCatalogDelete struct {
+	id   string
+	done chan bool
+}
+
+func newCatalogBatcher(api *squareapi.Client, window time.Duration) *catalogBatcher {
+	return &catalogBatcher{
+		api:    api,
+		window: window,
+	}
+}
+
+// upsert upserts object, along with any other objects upserted within the batch window.
+func (b *catalogBatcher) upsert(ctx context.Context, object *squareapi.CatalogObject) (*squareapi.CatalogObject, error) {
+	count, err := squareapi.CountCatalogObjects(object)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pendingCatalogUpsert{
+		object: object,
+		count:  count,
+		done:   make(chan *catalogUpsertResult, 1),
+	}
+
+	b.mu.Lock()
+
+	// Flush the pending upserts first if this one would take them over the limit.
+	if b.upsertCount+count > squareapi.CatalogMaxTotalObjects {
+		go b.sendUpserts(b.takeUpserts())
+	}
+
+	b.upserts = append(b.upserts, p)
+	b.upsertCount += count
+
+	if len(b.upserts) == 1 {
+		time.AfterFunc(b.window, b.flushUpserts)
+	}
+	b.mu.Unlock()
+
+	var res *catalogUpsertResult
+
+	select {
+	case <-ctx.Done():
+		if b.removeUpsert(p) {
+			return nil, fmt.Errorf("error waiting for batch upsert: %w", ctx.Err())
+		}
+
+		// The upsert is already part of a batch being sent, whose result must be reported so that the object
+		// isn't left untracked.
+		res = <-p.done
+		if res.alone {
+			return nil, fmt.Errorf("error waiting for batch upsert: %w", ctx.Err())
+		}
+	case res = <-p.done:
+	}
+
+	if !res.alone {
+		return res.object, nil
+	}
+
+	idempotencyKey, err := uuid.NewV4()
+	if err != nil {
+		return nil, fmt.Errorf("error creating idempotency key: %w", err)
+	}
+
+	return b.api.UpsertCatalogObject(ctx, idempotencyKey.String(), object)
+}
+
+// removeUpsert removes p from the pending upserts, reporting whether it was still pending.
+func (b *catalogBatcher) removeUpsert(p *pendingCatalogUpsert) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, pending := range b.upserts {
+		if pending == p {
+			b.upserts = append(b.upserts[:i], b.upserts[i+1:]...)
+			b.upsertCount -= p.count
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *catalogBatcher) flushUpserts() {
+	b.mu.Lock()
+	pending := b.takeUpserts()
+	b.mu.Unlock()
+
+	b.sendUpserts(pending)
+}
+
+// takeUpserts removes and returns the pending upserts.  It must be called with the lock held.
+func (b *catalogBatcher) takeUpserts() []*pendingCatalogUpsert {
+	pending := b.upserts
+	b.upserts = nil
+	b.upsertCount = 0
+
+	return pending
+}
+
+func (b *catalogBatcher) sendUpserts(pending []*pendingCatalogUpsert) {
+	// A timer may fire after its batch was already flushed for being full.
+	if len(pending) == 0 {
+		return
+	}
+
+	if len(pending) == 1 {
+		pending[0].done <- &catalogUpsertResult{alone: true}
+		return
+	}
+
+	objects := make([]*squareapi.CatalogObject, len(pending))
+	for i, p := range pending {
+		objects[i] = p.object
+	}
+
+	var res []*squareapi.CatalogObject
+
+	idempotencyKey, err := uuid.NewV4()
+	if err == nil {
+		// The batch is made on behalf of several resources, so it isn't tied to any one of their contexts.
+		// Square commits each batch separately, so when some fail, the objects that came back were still
+		// upserted, and only the rest are retried.
+		res, err = b.api.BatchUpsertCatalogObjects(context.Background(), idempotencyKey.String(), objects)
+		if err != nil {
+			log.Printf("[WARN] batch upsert failed, upserting the objects it didn't upsert on their own: %v", err)
+		}
+	}
+
+	for i, p := range pending {
+		if res == nil || res[i] == nil {
+			p.done <- &catalogUpsertResult{alone: true}
+			continue
+		}
+
+		p.done <- &catalogUpsertResult{object: res[i]}
+	}
+}
+
+// delete deletes the object with the given id, along with any other objects deleted within the batch
+// window.
+func (b *catalogBatcher) delete(ctx context.Context, id string) error {
+	p := &pendingCatalogDelete{
+		id:   id,
+		done: make(chan bool, 1),
+	}
+
+	b.mu.Lock()
+	b.deletes = append(b.deletes, p)
+
+	switch len(b.deletes) {
+	case 1:
+		time.AfterFunc(b.window, b.flushDeletes)
+	case squareapi.CatalogMaxDeleteObjects:
+		// Square only accepts so many ids in a batch delete, so send the pending deletes now, and start a
+		// new batch with the next one.
+		go b.sendDeletes(b.takeDeletes())
+	}
+	b.mu.Unlock()
+
+	var deleted bool
+
+	select {
+	case <-ctx.Done():
+		if b.removeDelete(p) {
+			return fmt.Errorf("error waiting for batch delete: %w", ctx.Err())
+		}
+
+		// The delete is already part of a batch being sent, so wait to find out whether it was made.
+		if deleted = <-p.done; !deleted {
+			return fmt.Errorf("error waiting for batch delete: %w", ctx.Err())
+		}
+	case deleted = <-p.done:
+	}
+
+	if deleted {
+		return nil
+	}
+
+	_, err := b.api.DeleteCatalogObject(ctx, id)
+
+	return err
+}
+
+// removeDelete removes p from the pending deletes, reporting whether it was still pending.
+func (b *catalogBatcher) removeDelete(p *pendingCatalogDelete) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, pending := range b.deletes {
+		if pending == p {
+			b.deletes = append(b.deletes[:i], b.deletes[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *catalogBatcher) flushDeletes() {
+	b.mu.Lock()
+	pending := b.takeDeletes()
+	b.mu.Unlock()
+
+	b.sendDeletes(pending)
+}
+
+// takeDeletes removes and returns the pending deletes.  It must be called with the lock held.
+func (b *catalogBatcher) takeDeletes() []*pendingCatalogDelete {
+	pending := b.deletes
+	b.deletes = nil
+
+	return pending
+}
+
+func (b *catalogBatcher) sendDeletes(pending []*pendingCatalogDelete) {
+	// A timer may fire after its batch was already flushed for being full.
+	if len(pending) == 0 {
+		return
+	}
+
+	if len(pending) == 1 {
+		pending[0].done <- false
+		return
+	}
+
+	ids := make([]string, len(pending))
+	for i, p := range pending {
+		ids[i] = p.id
+	}
+
+	_, err := b.api.BatchDeleteCatalogObjects(context.Background(), ids)
+
+	for _, p := range pending {
+		p.done <- err == nil
+	}
+}
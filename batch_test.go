@@ -0,0 +1,391 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testCountingClient returns an api client for the fake server that counts the requests it sends by path.
+func testCountingClient(t *testing.T) (*squareapi.Client, func() map[string]int) {
+	t.Helper()
+
+	var (
+		mu     sync.Mutex
+		counts = map[string]int{}
+	)
+
+	transport, err := newBaseURLTransport(testBaseURL, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		counts[r.Method+" "+strings.TrimPrefix(r.URL.Path, "/v2/")]++
+		mu.Unlock()
+
+		return http.DefaultTransport.RoundTrip(r) //nolint:wrapcheck
+	}))
+	if err != nil {
+		t.Fatalf("error creating transport: %v", err)
+	}
+
+	api, err := squareapi.NewClient(testFakeToken, objects.Sandbox, &http.Client{Transport: transport}, -1)
+	if err != nil {
+		t.Fatalf("error creating api client: %v", err)
+	}
+
+	return api, func() map[string]int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		c := make(map[string]int, len(counts))
+		for k, v := range counts {
+			c[k] = v
+		}
+
+		return c
+	}
+}
+
+// testCountingMeta returns provider meta whose catalog batcher and read cache count the requests they send
+// by path.
+func testCountingMeta(t *testing.T) (*providerMeta, func() map[string]int) {
+	t.Helper()
+
+	meta := testProviderMeta(t, nil)
+	api, counts := testCountingClient(t)
+
+	counting := *meta
+	counting.catalog = newCatalogBatcher(api, 200*time.Millisecond)
+	counting.cache = newCatalogCache(api)
+
+	return &counting, counts
+}
+
+// testApplyConcurrently applies each config to its state at the same time, the way terraform applies
+// independent resources.
+func testApplyConcurrently(t *testing.T, meta *providerMeta, states []*terraform.InstanceState, configs []map[string]interface{}) ([]*terraform.InstanceState, []diag.Diagnostics) {
+	t.Helper()
+
+	newStates := make([]*terraform.InstanceState, len(configs))
+	diags := make([]diag.Diagnostics, len(configs))
+
+	var wg sync.WaitGroup
+
+	for i := range configs {
+		i := i
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			r := resourceCatalogCategory()
+
+			diff, err := r.Diff(context.Background(), states[i], terraform.NewResourceConfigRaw(configs[i]), meta)
+			if err != nil {
+				diags[i] = diag.FromErr(err)
+				return
+			}
+
+			newStates[i], diags[i] = r.Apply(context.Background(), states[i], diff, meta)
+		}()
+	}
+
+	wg.Wait()
+
+	return newStates, diags
+}
+
+func TestCatalogBatcher(t *testing.T) {
+	t.Parallel()
+
+	meta, counts := testCountingMeta(t)
+
+	const n = 20
+
+	configs := make([]map[string]interface{}, n)
+	for i := range configs {
+		configs[i] = map[string]interface{}{"name": fmt.Sprintf("batch-category-%d", i)}
+	}
+
+	states, diags := testApplyConcurrently(t, meta, make([]*terraform.InstanceState, n), configs)
+
+	for i, d := range diags {
+		if d.HasError() {
+			t.Fatalf("error creating category %d: %v", i, d)
+		}
+
+		if states[i].Attributes["name"] != configs[i]["name"] {
+			t.Fatalf("expected category %d to be %s, found %v", i, configs[i]["name"], states[i].Attributes)
+		}
+	}
+
+	if c := counts(); c["POST catalog/batch-upsert"] != 1 || c["POST catalog/object"] != 0 {
+		t.Fatalf("expected creates to be made in one batch, found %v", c)
+	}
+
+	// Update one of the categories out of band, so that its part of the next batch conflicts.
+	testBumpCatalogCategory(t, meta, states[0], "batch-category-remote")
+
+	for i := range configs {
+		configs[i]["name"] = fmt.Sprintf("batch-category-%d-updated", i)
+	}
+
+	states, diags = testApplyConcurrently(t, meta, states, configs)
+
+	if !diags[0].HasError() || !strings.Contains(diags[0][0].Summary, "Version conflict") {
+		t.Fatalf("expected version conflict for the category updated out of band, found %v", diags[0])
+	}
+
+	for i := 1; i < n; i++ {
+		if diags[i].HasError() {
+			t.Fatalf("error updating category %d: %v", i, diags[i])
+		}
+
+		if states[i].Attributes["name"] != configs[i]["name"] {
+			t.Fatalf("expected category %d to be %s, found %v", i, configs[i]["name"], states[i].Attributes)
+		}
+	}
+
+	if c := counts(); c["POST catalog/batch-upsert"] != 2 || c["POST catalog/object"] != n {
+		t.Fatalf("expected a failed batch to fall back to one upsert per category, found %v", c)
+	}
+
+	var wg sync.WaitGroup
+
+	errs := make([]error, n)
+
+	for i := range states {
+		i := i
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			errs[i] = meta.catalog.delete(context.Background(), states[i].ID)
+		}()
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("error deleting category %d: %v", i, err)
+		}
+
+		if _, err := meta.api.RetrieveCatalogObject(context.Background(), states[i].ID); !isNotFound(err) {
+			t.Fatalf("expected category %d to be deleted, found %v", i, err)
+		}
+	}
+
+	if c := counts(); c["POST catalog/batch-delete"] != 1 || c["DELETE catalog/object/"+states[0].ID] != 0 {
+		t.Fatalf("expected deletes to be made in one batch, found %v", c)
+	}
+}
+
+func TestCatalogBatcherPartialFailure(t *testing.T) {
+	t.Parallel()
+
+	meta, counts := testCountingMeta(t)
+
+	stale, err := meta.api.UpsertCatalogObject(context.Background(), t.Name(), squareapi.NewCatalogObject(&objects.CatalogObject{
+		ID:   "#id",
+		Type: &objects.CatalogCategory{Name: "partial-stale"},
+	}))
+	if err != nil {
+		t.Fatalf("error creating category: %v", err)
+	}
+
+	stale.Version--
+
+	// Items with a variation count as two objects each, so they fill the first batch on their own, and the
+	// stale category goes into a second batch, which fails.
+	pending := []*pendingCatalogUpsert{}
+
+	for i := 0; i < squareapi.CatalogMaxBatchObjects/2; i++ {
+		pending = append(pending, &pendingCatalogUpsert{
+			object: squareapi.NewCatalogObject(&objects.CatalogObject{
+				ID: "#id",
+				Type: &objects.CatalogItem{
+					Name: fmt.Sprintf("partial-item-%d", i),
+					Variations: []*objects.CatalogObject{{
+						ID:   "#variation",
+						Type: &objects.CatalogItemVariation{ItemID: "#id", Name: "variation", PricingType: objects.CatalogPricingTypeVariable},
+					}},
+				},
+			}),
+			count: 2,
+			done:  make(chan *catalogUpsertResult, 1),
+		})
+	}
+
+	pending = append(pending, &pendingCatalogUpsert{object: stale, count: 1, done: make(chan *catalogUpsertResult, 1)})
+
+	meta.catalog.sendUpserts(pending)
+
+	for i, p := range pending[:len(pending)-1] {
+		res := <-p.done
+		if res.alone || res.object == nil || strings.HasPrefix(res.object.ID, "#") {
+			t.Fatalf("expected item %d to be upserted by the batch, found %+v", i, res)
+		}
+	}
+
+	if res := <-pending[len(pending)-1].done; !res.alone {
+		t.Fatalf("expected the stale category to fall back to its own upsert, found %+v", res)
+	}
+
+	if c := counts(); c["POST catalog/batch-upsert"] != 1 {
+		t.Fatalf("expected one batch upsert, found %v", c)
+	}
+}
+
+func TestCatalogBatcherDeleteLimit(t *testing.T) {
+	t.Parallel()
+
+	meta, counts := testCountingMeta(t)
+
+	const n = squareapi.CatalogMaxDeleteObjects + 50
+
+	categories := make([]*squareapi.CatalogObject, n)
+	for i := range categories {
+		categories[i] = squareapi.NewCatalogObject(&objects.CatalogObject{
+			ID:   "#id",
+			Type: &objects.CatalogCategory{Name: fmt.Sprintf("delete-limit-%d", i)},
+		})
+	}
+
+	created, err := meta.api.BatchUpsertCatalogObjects(context.Background(), t.Name(), categories)
+	if err != nil {
+		t.Fatalf("error creating categories: %v", err)
+	}
+
+	var wg sync.WaitGroup
+
+	errs := make([]error, n)
+
+	for i := range created {
+		i := i
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			errs[i] = meta.catalog.delete(context.Background(), created[i].ID)
+		}()
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("error deleting category %d: %v", i, err)
+		}
+
+		if _, err := meta.api.RetrieveCatalogObject(context.Background(), created[i].ID); !isNotFound(err) {
+			t.Fatalf("expected category %d to be deleted, found %v", i, err)
+		}
+	}
+
+	c := counts()
+	if c["POST catalog/batch-delete"] != 2 {
+		t.Fatalf("expected deletes to be split into 2 batches, found %v", c)
+	}
+
+	for k, v := range c {
+		if strings.HasPrefix(k, "DELETE ") {
+			t.Fatalf("expected no deletes to fall back to their own request, found %d %s", v, k)
+		}
+	}
+}
+
+func TestCatalogBatcherCancelled(t *testing.T) {
+	t.Parallel()
+
+	meta, _ := testCountingMeta(t)
+
+	categories := make([]*squareapi.CatalogObject, 2)
+
+	for i, name := range []string{"cancelled-delete", "kept-delete"} {
+		idempotencyKey, err := uuid.NewV4()
+		if err != nil {
+			t.Fatalf("error creating idempotency key: %v", err)
+		}
+
+		created, err := meta.api.UpsertCatalogObject(context.Background(), idempotencyKey.String(), squareapi.NewCatalogObject(&objects.CatalogObject{
+			ID:   "#id",
+			Type: &objects.CatalogCategory{Name: name},
+		}))
+		if err != nil {
+			t.Fatalf("error creating category: %v", err)
+		}
+
+		categories[i] = created
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	errs := make([]error, 2)
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		_, errs[0] = meta.catalog.upsert(context.Background(), squareapi.NewCatalogObject(&objects.CatalogObject{
+			ID:   "#id",
+			Type: &objects.CatalogCategory{Name: "kept-upsert"},
+		}))
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		errs[1] = meta.catalog.delete(context.Background(), categories[1].ID)
+	}()
+
+	if _, err := meta.catalog.upsert(ctx, squareapi.NewCatalogObject(&objects.CatalogObject{
+		ID:   "#id",
+		Type: &objects.CatalogCategory{Name: "cancelled-upsert"},
+	})); err == nil {
+		t.Fatalf("expected error upserting with a cancelled context")
+	}
+
+	if err := meta.catalog.delete(ctx, categories[0].ID); err == nil {
+		t.Fatalf("expected error deleting with a cancelled context")
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("error writing alongside cancelled writes: %v", err)
+		}
+	}
+
+	found, err := meta.api.ListCatalog(context.Background(), []objects.CatalogObjectEnumType{objects.CatalogObjectEnumTypeCategory})
+	if err != nil {
+		t.Fatalf("error listing categories: %v", err)
+	}
+
+	names := map[string]bool{}
+	for _, o := range found {
+		names[o.Type.(*objects.CatalogCategory).Name] = true
+	}
+
+	if names["cancelled-upsert"] || !names["kept-upsert"] || !names["cancelled-delete"] || names["kept-delete"] {
+		t.Fatalf("expected only the writes that weren't cancelled to be made, found %v", names)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+)
+
+// cachedCatalogTypes are the types of the catalog objects this provider manages, which are the ones the cache
+// lists.
+var cachedCatalogTypes = []objects.CatalogObjectEnumType{
+	objects.CatalogObjectEnumTypeItem,
+	objects.CatalogObjectEnumTypeCategory,
+	objects.CatalogObjectEnumTypeTax,
+	objects.CatalogObjectEnumTypeDiscount,
+	objects.CatalogObjectEnumTypeModifierList,
+	objects.CatalogObjectEnumTypeImage,
+	objects.CatalogObjectEnumTypeItemOption,
+	objects.CatalogObjectEnumTypePricingRule,
+	objects.CatalogObjectEnumTypeProductSet,
+	objects.CatalogObjectEnumTypeTimePeriod,
+}
+
+// catalogCache serves catalog reads from memory, so refreshing a large state doesn't take a request per
+// resource.  The first read lists every object of the types this provider manages, and objects written since
+// are dropped from the cache and retrieved on their own.  If the list fails, every read is retrieved on its
+// own instead.
+type catalogCache struct {
+	api *squareapi.Client
+
+	// mu is held while the catalog is listed, so concurrent first reads wait for one list instead of each
+	// making their own, and so writes can't be invalidated before a list that predates them is stored.
+	mu      sync.Mutex
+	loaded  bool
+	objects map[string]*squareapi.CatalogObject
+}
+
+func newCatalogCache(api *squareapi.Client) *catalogCache {
+	return &catalogCache{
+		api:     api,
+		objects: map[string]*squareapi.CatalogObject{},
+	}
+}
+
+// retrieve returns the catalog object with the given id, from the cache if it's there.
+func (c *catalogCache) retrieve(ctx context.Context, id string) (*squareapi.CatalogObject, error) {
+	if object := c.cached(ctx, id); object != nil {
+		return object, nil
+	}
+
+	return c.api.RetrieveCatalogObject(ctx, id)
+}
+
+func (c *catalogCache) cached(ctx context.Context, id string) *squareapi.CatalogObject {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.loaded {
+		// The list isn't retried, so that a failing list doesn't slow down every read.
+		c.loaded = true
+
+		all, err := c.api.ListCatalog(ctx, cachedCatalogTypes)
+		if err != nil {
+			log.Printf("[WARN] unable to list catalog, retrieving objects one at a time: %v", err)
+			return nil
+		}
+
+		for _, o := range all {
+			c.objects[o.ID] = o
+		}
+	}
+
+	return c.objects[id]
+}
+
+// invalidate drops the objects with the given ids from the cache, after they've been written.
+func (c *catalogCache) invalidate(ids ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range ids {
+		delete(c.objects, id)
+	}
+}
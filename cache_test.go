@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCatalogCache(t *testing.T) {
+	t.Parallel()
+
+	meta, counts := testCountingMeta(t)
+	r := resourceCatalogCategory()
+
+	const n = 5
+
+	states := make([]*terraform.InstanceState, n)
+
+	for i := range states {
+		var diags diag.Diagnostics
+
+		// The categories are created with another provider, so the cache starts out empty.
+		states[i], diags = testApplyCatalogCategory(t, testProviderMeta(t, nil), nil, fmt.Sprintf("cache-category-%d", i))
+		if diags.HasError() {
+			t.Fatalf("error creating category %d: %v", i, diags)
+		}
+	}
+
+	before := counts()
+
+	refresh := func(i int) *terraform.InstanceState {
+		t.Helper()
+
+		state, diags := r.RefreshWithoutUpgrade(context.Background(), states[i], meta)
+		if diags.HasError() {
+			t.Fatalf("error refreshing category %d: %v", i, diags)
+		}
+
+		return state
+	}
+
+	for i := range states {
+		if state := refresh(i); state.Attributes["name"] != fmt.Sprintf("cache-category-%d", i) {
+			t.Fatalf("unexpected state for category %d: %v", i, state.Attributes)
+		}
+	}
+
+	after := counts()
+	if after["GET catalog/list"]-before["GET catalog/list"] != 1 || after["GET catalog/object/"+states[0].ID] != 0 {
+		t.Fatalf("expected refreshes to be served by one list, found %v", after)
+	}
+
+	// Writing a category drops it from the cache, so its next read sees the write.
+	updated, diags := testApply(t, r, meta, states[0], map[string]interface{}{"name": "cache-category-updated"})
+	if diags.HasError() {
+		t.Fatalf("error updating category: %v", diags)
+	}
+
+	states[0] = updated
+
+	if state := refresh(0); state.Attributes["name"] != "cache-category-updated" {
+		t.Fatalf("expected refresh to see the update, found %v", state.Attributes)
+	}
+
+	d := r.Data(states[1])
+	if diags := r.DeleteContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("error deleting category: %v", diags)
+	}
+
+	if state := refresh(1); state != nil && state.ID != "" {
+		t.Fatalf("expected deleted category to be removed from state, found %v", state)
+	}
+
+	final := counts()
+	if final["GET catalog/list"] != after["GET catalog/list"] || final["GET catalog/object/"+states[0].ID] != 1 || final["GET catalog/object/"+states[1].ID] != 1 {
+		t.Fatalf("expected only written categories to be retrieved again, found %v", final)
+	}
+}
+
+func TestCatalogCacheListFailure(t *testing.T) {
+	t.Parallel()
+
+	state, diags := testApplyCatalogCategory(t, testProviderMeta(t, nil), nil, "cache-list-failure")
+	if diags.HasError() {
+		t.Fatalf("error creating category: %v", diags)
+	}
+
+	var (
+		mu    sync.Mutex
+		lists []string
+	)
+
+	transport, err := newBaseURLTransport(testBaseURL, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(r.URL.Path, "/catalog/list") {
+			mu.Lock()
+			lists = append(lists, r.URL.Query().Get("types"))
+			mu.Unlock()
+
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"errors":[{"category":"API_ERROR","code":"INTERNAL_SERVER_ERROR"}]}`)),
+				Request:    r,
+			}, nil
+		}
+
+		return http.DefaultTransport.RoundTrip(r) //nolint:wrapcheck
+	}))
+	if err != nil {
+		t.Fatalf("error creating transport: %v", err)
+	}
+
+	api, err := squareapi.NewClient(testFakeToken, objects.Sandbox, &http.Client{Transport: transport}, -1)
+	if err != nil {
+		t.Fatalf("error creating api client: %v", err)
+	}
+
+	meta := *testProviderMeta(t, nil)
+	meta.cache = newCatalogCache(api)
+	r := resourceCatalogCategory()
+
+	for i := 0; i < 2; i++ {
+		refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), state, &meta)
+		if diags.HasError() {
+			t.Fatalf("error refreshing category: %v", diags)
+		}
+
+		if refreshed.Attributes["name"] != "cache-list-failure" {
+			t.Fatalf("unexpected state: %v", refreshed.Attributes)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// The list isn't retried, and only asks for the types the provider manages.
+	if len(lists) != 1 || !strings.Contains(lists[0], "CATEGORY") || strings.Contains(lists[0], "CUSTOM_ATTRIBUTE_DEFINITION") {
+		t.Fatalf("expected one list of managed types, found %v", lists)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// errorCodeVersionMismatch is returned by square when an upsert is made against an outdated version of an
+// object.  square-go doesn't define it.
+const errorCodeVersionMismatch objects.ErrorCode = "VERSION_MISMATCH"
+
+// maxVersionConflictRetries limits how many times an upsert is retried when the object keeps changing
+// remotely.
+const maxVersionConflictRetries = 3
+
+// isVersionMismatch reports whether err was caused by upserting an outdated version of an object.
+func isVersionMismatch(err error) bool {
+	var errList *objects.ErrorList
+	if !errors.As(err, &errList) {
+		return false
+	}
+
+	for _, e := range errList.Errors {
+		if e.Code == errorCodeVersionMismatch {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveCatalogVersionConflict is called when upserting object failed because it has changed in square
+// since it was last read.  The remote object is read back into d, so the state reflects what's in square.
+// If none of the attributes managed by terraform changed and the provider is configured to retry version
+// conflicts, the upsert is retried against the remote version.  Otherwise a diagnostic describing the
+// conflict is returned.
+func resolveCatalogVersionConflict(ctx context.Context, meta *providerMeta, d *schema.ResourceData, object *squareapi.CatalogObject, objectToResource ObjectToResource) (*squareapi.CatalogObject, diag.Diagnostics) {
+	expectedVersion := object.Version
+
+	for attempt := 0; ; attempt++ {
+		remote, err := meta.api.RetrieveCatalogObject(ctx, object.ID)
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("error making network call to retrieve conflicting object: %w", err))
+		}
+
+		if remote == nil || remote.IsDeleted {
+			return nil, diag.Errorf("catalog object %s was deleted while being updated", object.ID)
+		}
+
+		changed, err := catalogRemoteChanges(remote, d, meta, objectToResource)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		if len(changed) > 0 || !meta.retryVersionConflicts || attempt >= maxVersionConflictRetries {
+			return nil, catalogVersionConflictDiagnostics(remote, expectedVersion, changed, meta.retryVersionConflicts)
+		}
+
+		log.Printf("[INFO] catalog object %s changed remotely from version %d to %d without changing managed attributes, retrying", object.ID, object.Version, remote.Version)
+
+		idempotencyKey, err := uuid.NewV4()
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("error creating idempotency key: %w", err))
+		}
+
+		object.Version = remote.Version
+
+		res, err := meta.api.UpsertCatalogObject(ctx, idempotencyKey.String(), object)
+		if err == nil {
+			return res, nil
+		}
+
+		if !isVersionMismatch(err) {
+			return nil, diag.FromErr(fmt.Errorf("error making network call to upsert object: %w", err))
+		}
+	}
+}
+
+// catalogRemoteChanges reads the remote object into d and returns the top level attributes whose remote
+// values differ from those in the prior state.
+func catalogRemoteChanges(remote *squareapi.CatalogObject, d *schema.ResourceData, meta *providerMeta, objectToResource ObjectToResource) ([]string, error) {
+	if err := objectToResource(remote, d, meta); err != nil {
+		return nil, fmt.Errorf("error reading conflicting object: %w", err)
+	}
+
+	keys := map[string]struct{}{}
+
+	for k := range d.State().Attributes {
+		key := strings.SplitN(k, ".", 2)[0] //nolint:gomnd
+		if key == "id" || key == "version" {
+			continue
+		}
+
+		keys[key] = struct{}{}
+	}
+
+	changed := []string{}
+
+	for key := range keys {
+		old, _ := d.GetChange(key)
+		if !reflect.DeepEqual(normalizeValue(old), normalizeValue(d.Get(key))) {
+			changed = append(changed, key)
+		}
+	}
+
+	sort.Strings(changed)
+
+	return changed, nil
+}
+
+// normalizeValue converts sets into maps keyed by their hash codes, so values read out of resource data can
+// be compared with reflect.DeepEqual.
+func normalizeValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case *schema.Set:
+		m := make(map[int]interface{}, t.Len())
+		for _, e := range t.List() {
+			m[t.F(e)] = normalizeValue(e)
+		}
+
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(t))
+		for i, e := range t {
+			l[i] = normalizeValue(e)
+		}
+
+		return l
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[k] = normalizeValue(e)
+		}
+
+		return m
+	}
+
+	return v
+}
+
+func catalogVersionConflictDiagnostics(remote *squareapi.CatalogObject, expectedVersion int, changed []string, retry bool) diag.Diagnostics {
+	objectType, name := catalogObjectTypeAndName(remote.CatalogObject)
+
+	description := fmt.Sprintf("%s %s", strings.ToLower(string(objectType)), remote.ID)
+	if name != "" {
+		description = fmt.Sprintf("%s %s (%q)", strings.ToLower(string(objectType)), remote.ID, name)
+	}
+
+	var detail string
+
+	switch {
+	case len(changed) > 0:
+		detail = fmt.Sprintf("The following attributes were changed outside of terraform: %s.", strings.Join(changed, ", "))
+	case retry:
+		detail = "The object kept changing outside of terraform while retrying."
+	default:
+		detail = fmt.Sprintf("None of the attributes managed by terraform were changed.  Set %s in the provider to retry automatically in this case.", ProviderRetryVersionConflicts)
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Version conflict updating catalog %s", description),
+			Detail: fmt.Sprintf("Expected version %d, but square has version %d.  %s  The state has been refreshed from square, review the plan and apply again.",
+				expectedVersion, remote.Version, detail),
+		},
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestIsVersionMismatch(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"version mismatch": {
+			err:      &objects.ErrorList{Errors: []*objects.Error{{Code: errorCodeVersionMismatch}}},
+			expected: true,
+		},
+		"other error": {
+			err:      &objects.ErrorList{Errors: []*objects.Error{{Code: objects.ErrorCodeNotFound}}},
+			expected: false,
+		},
+		"unexpected code": {
+			err:      objects.UnexpectedCodeError(400),
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if found := isVersionMismatch(test.err); found != test.expected {
+				t.Fatalf("expected %t, found %t", test.expected, found)
+			}
+		})
+	}
+}
+
+func testApplyCatalogCategory(t *testing.T, meta *providerMeta, state *terraform.InstanceState, name string) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+
+	return testApply(t, resourceCatalogCategory(), meta, state, map[string]interface{}{"name": name})
+}
+
+// testBumpCatalogCategory upserts the category outside of terraform, changing its version.
+func testBumpCatalogCategory(t *testing.T, meta *providerMeta, state *terraform.InstanceState, name string) {
+	t.Helper()
+
+	d := resourceCatalogCategory().Data(state)
+
+	object, err := catalogCategoryResourceToObject(d, meta)
+	if err != nil {
+		t.Fatalf("error converting category: %v", err)
+	}
+
+	object.Type.(*objects.CatalogCategory).Name = name
+
+	if _, err := meta.api.UpsertCatalogObject(context.Background(), t.Name()+state.Attributes["version"], object); err != nil {
+		t.Fatalf("error updating category out of band: %v", err)
+	}
+}
+
+func TestCatalogVersionConflict(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		retry        bool
+		remoteName   string
+		expectedName string
+		errContains  []string
+	}{
+		"managed attribute changed": {
+			retry:        true,
+			remoteName:   "remote-name",
+			expectedName: "remote-name",
+			errContains:  []string{"Version conflict updating catalog category", "name"},
+		},
+		"unmanaged change without retry": {
+			retry:        false,
+			remoteName:   "original",
+			expectedName: "original",
+			errContains:  []string{ProviderRetryVersionConflicts},
+		},
+		"unmanaged change with retry": {
+			retry:        true,
+			remoteName:   "original",
+			expectedName: "updated",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			meta := testProviderMeta(t, map[string]interface{}{ProviderRetryVersionConflicts: test.retry})
+
+			state, diags := testApplyCatalogCategory(t, meta, nil, "original")
+			if diags.HasError() {
+				t.Fatalf("error creating category: %v", diags)
+			}
+
+			testBumpCatalogCategory(t, meta, state, test.remoteName)
+
+			state, diags = testApplyCatalogCategory(t, meta, state, "updated")
+
+			if len(test.errContains) == 0 {
+				if diags.HasError() {
+					t.Fatalf("unexpected error updating category: %v", diags)
+				}
+			} else {
+				if !diags.HasError() {
+					t.Fatalf("expected version conflict")
+				}
+
+				message := diags[0].Summary + " " + diags[0].Detail
+				for _, s := range test.errContains {
+					if !strings.Contains(message, s) {
+						t.Fatalf("expected %q in diagnostic, found %q", s, message)
+					}
+				}
+			}
+
+			if found := state.Attributes["name"]; found != test.expectedName {
+				t.Fatalf("expected name %q in state, found %q", test.expectedName, found)
+			}
+		})
+	}
+}
+
+func TestNormalizeValue(t *testing.T) {
+	t.Parallel()
+
+	a := schema.NewSet(schema.HashString, []interface{}{"a", "b"})
+	b := schema.NewSet(schema.HashString, []interface{}{"b", "a"})
+	c := schema.NewSet(schema.HashString, []interface{}{"a"})
+
+	if !reflect.DeepEqual(normalizeValue([]interface{}{a}), normalizeValue([]interface{}{b})) {
+		t.Fatalf("expected equal sets to be equal")
+	}
+
+	if reflect.DeepEqual(normalizeValue(a), normalizeValue(c)) {
+		t.Fatalf("expected different sets to be different")
+	}
+}
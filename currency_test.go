@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestValidateCurrency(t *testing.T) {
+	t.Parallel()
+
+	for _, currency := range []string{"USD", "CAD", "GBP", "JPY"} {
+		if diags := validateCurrency(currency, cty.Path{}); diags.HasError() {
+			t.Fatalf("unexpected error validating %s: %v", currency, diags)
+		}
+	}
+
+	for _, currency := range []string{"", "usd", "US", "DOLLARS", "XYZ"} {
+		if diags := validateCurrency(currency, cty.Path{}); !diags.HasError() {
+			t.Fatalf("expected error validating %q", currency)
+		}
+	}
+}
+
+func TestStateCurrency(t *testing.T) {
+	t.Parallel()
+
+	meta := &providerMeta{defaultCurrency: "USD"}
+
+	if c := meta.stateCurrency("USD", ""); c != "" {
+		t.Fatalf("expected default currency to be left unset, found %s", c)
+	}
+
+	if c := meta.stateCurrency("USD", "USD"); c != "USD" {
+		t.Fatalf("expected configured currency to be stored, found %s", c)
+	}
+
+	if c := meta.stateCurrency("CAD", ""); c != "CAD" {
+		t.Fatalf("expected drifted currency to be stored, found %s", c)
+	}
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCatalogDiscount() *schema.Resource {
+	return &schema.Resource{
+		Schema:      dataSourceCatalogSchema(resourceCatalogDiscount().Schema),
+		ReadContext: dataSourceCatalogRead(objects.CatalogObjectEnumTypeDiscount, catalogDiscountObjectToResource),
+	}
+}
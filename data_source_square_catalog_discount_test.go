@@ -0,0 +1,53 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const dataSourceCatalogDiscountBlock = `resource "square_catalog_discount" "test_data_discount" {
+	name = "my-data-discount"
+	type = "FIXED_PERCENTAGE"
+	percentage = "15.0"
+}
+
+data "square_catalog_discount" "by_name" {
+	name = square_catalog_discount.test_data_discount.name
+}
+
+`
+
+const dataSourceCatalogDiscountMissingBlock = `data "square_catalog_discount" "missing" {
+	name = "my-missing-data-discount"
+}
+
+`
+
+func TestDataSourceCatalogDiscount(t *testing.T) {
+	t.Parallel()
+
+	token := testAccToken()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"square": func() (*schema.Provider, error) { return Provider(), nil }, //nolint:unparam
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerBlock(token) + dataSourceCatalogDiscountBlock,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.square_catalog_discount.by_name", "id", "square_catalog_discount.test_data_discount", "id"),
+					resource.TestCheckResourceAttr("data.square_catalog_discount.by_name", "type", "FIXED_PERCENTAGE"),
+					resource.TestCheckResourceAttr("data.square_catalog_discount.by_name", "percentage", "15.0"),
+				),
+			},
+			{
+				Config:      providerBlock(token) + dataSourceCatalogDiscountMissingBlock,
+				ExpectError: regexp.MustCompile(`no catalog object of type DISCOUNT found with name "my-missing-data-discount"`),
+			},
+		},
+	})
+}
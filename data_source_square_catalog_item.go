@@ -0,0 +1,13 @@
+package main
+
+import (
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCatalogItem() *schema.Resource {
+	return &schema.Resource{
+		Schema:      dataSourceCatalogSchema(resourceCatalogItem().Schema),
+		ReadContext: dataSourceCatalogRead(objects.CatalogObjectEnumTypeItem, catalogItemObjectToResource),
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const dataSourceCatalogItemBlock = `resource "square_catalog_item" "test_data_item" {
+	name = "my-data-item"
+
+	variation {
+		name = "variation1"
+		pricing_type = "FIXED_PRICING"
+		amount = 500
+	}
+}
+
+data "square_catalog_item" "by_name" {
+	name = square_catalog_item.test_data_item.name
+}
+
+data "square_catalog_item" "by_id" {
+	id = square_catalog_item.test_data_item.id
+}
+
+`
+
+func TestDataSourceCatalogItem(t *testing.T) {
+	t.Parallel()
+
+	token := testAccToken()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"square": func() (*schema.Provider, error) { return Provider(), nil }, //nolint:unparam
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerBlock(token) + dataSourceCatalogItemBlock,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.square_catalog_item.by_name", "id", "square_catalog_item.test_data_item", "id"),
+					resource.TestCheckResourceAttrPair("data.square_catalog_item.by_name", "version", "square_catalog_item.test_data_item", "version"),
+					resource.TestCheckResourceAttr("data.square_catalog_item.by_name", "variation.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.square_catalog_item.by_name", "variation.*", map[string]string{
+						"name":         "variation1",
+						"pricing_type": "FIXED_PRICING",
+						"amount":       "500",
+					}),
+					resource.TestCheckResourceAttr("data.square_catalog_item.by_id", "name", "my-data-item"),
+					resource.TestCheckResourceAttrPair("data.square_catalog_item.by_id", "variation.#", "square_catalog_item.test_data_item", "variation.#"),
+				),
+			},
+		},
+	})
+}
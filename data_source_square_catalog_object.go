@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCatalogObject() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		ReadContext: dataSourceCatalogObjectRead,
+	}
+}
+
+func dataSourceCatalogObjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	objectType := objects.CatalogObjectEnumType(d.Get("type").(string))
+
+	return dataSourceCatalogRead(objectType, catalogObjectObjectToResource)(ctx, d, m)
+}
+
+func catalogObjectObjectToResource(o *squareapi.CatalogObject, d *schema.ResourceData, meta *providerMeta) error {
+	d.SetId(o.ID)
+
+	objectType, name := catalogObjectTypeAndName(o.CatalogObject)
+
+	if err := d.Set("name", name); err != nil {
+		return fmt.Errorf("error setting name: %w", err)
+	}
+
+	if err := d.Set("type", string(objectType)); err != nil {
+		return fmt.Errorf("error setting type: %w", err)
+	}
+
+	if err := d.Set("version", o.Version); err != nil {
+		return fmt.Errorf("error setting version: %w", err)
+	}
+
+	return nil
+}
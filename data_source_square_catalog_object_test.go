@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const dataSourceCatalogObjectBlock = `resource "square_catalog_category" "test_data_object" {
+	name = "my-data-object"
+}
+
+data "square_catalog_object" "by_name" {
+	name = square_catalog_category.test_data_object.name
+	type = "CATEGORY"
+}
+
+data "square_catalog_object" "by_id" {
+	id = square_catalog_category.test_data_object.id
+}
+
+`
+
+func TestDataSourceCatalogObject(t *testing.T) {
+	t.Parallel()
+
+	token := testAccToken()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"square": func() (*schema.Provider, error) { return Provider(), nil }, //nolint:unparam
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerBlock(token) + dataSourceCatalogObjectBlock,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.square_catalog_object.by_name", "id", "square_catalog_category.test_data_object", "id"),
+					resource.TestCheckResourceAttr("data.square_catalog_object.by_id", "name", "my-data-object"),
+					resource.TestCheckResourceAttr("data.square_catalog_object.by_id", "type", "CATEGORY"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceCatalogObjectByName(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+
+	for _, name := range []string{"lookup-category", "Lookup-Category", "lookup-category-other"} {
+		if _, diags := testApply(t, resourceCatalogCategory(), meta, nil, map[string]interface{}{"name": name}); diags.HasError() {
+			t.Fatalf("error creating category %s: %v", name, diags)
+		}
+	}
+
+	api, counts := testCountingClient(t)
+
+	counting := *meta
+	counting.api = api
+
+	state, diags := testReadDataSource(t, dataSourceCatalogObject(), &counting, map[string]interface{}{
+		"name": "Lookup-Category",
+		"type": "CATEGORY",
+	})
+	if diags.HasError() {
+		t.Fatalf("error reading catalog object: %v", diags)
+	}
+
+	if state.Attributes["name"] != "Lookup-Category" {
+		t.Fatalf("expected the category with the exact name, found %v", state.Attributes)
+	}
+
+	if c := counts(); c["POST catalog/search"] != 1 || c["GET catalog/list"] != 0 {
+		t.Fatalf("expected the lookup to search the catalog rather than list it, found %v", c)
+	}
+
+	if _, diags := testReadDataSource(t, dataSourceCatalogObject(), meta, map[string]interface{}{
+		"name": "lookup-category-missing",
+		"type": "CATEGORY",
+	}); !diags.HasError() || !strings.Contains(diags[0].Summary, "no catalog object of type CATEGORY found") {
+		t.Fatalf("expected not found error, found %v", diags)
+	}
+}
+
+func TestDataSourceCatalogObjectDeleted(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+
+	// The fake server forgets deleted objects, while square may still return them marked as deleted.
+	api, err := squareapi.NewClient(testFakeToken, objects.Sandbox, &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := `{"object": {"type": "CATEGORY", "id": "deleted-category", "is_deleted": true, "category_data": {"name": "deleted"}}}`
+
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+	})}, -1)
+	if err != nil {
+		t.Fatalf("error creating api client: %v", err)
+	}
+
+	deleted := *meta
+	deleted.api = api
+
+	if _, diags := testReadDataSource(t, dataSourceCatalogObject(), &deleted, map[string]interface{}{
+		"id": "deleted-category",
+	}); !diags.HasError() || !strings.Contains(diags[0].Summary, "no catalog object found with id deleted-category") {
+		t.Fatalf("expected not found error, found %v", diags)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// customerLookupFields are the attributes a customer may be looked up by.
+var customerLookupFields = []string{"id", "email_address", "phone_number", "reference_id"}
+
+func dataSourceCustomer() *schema.Resource {
+	dataSchema := computedSchemaMap(customerSchema())
+
+	for _, field := range customerLookupFields {
+		dataSchema[field] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: customerLookupFields,
+		}
+	}
+
+	return &schema.Resource{
+		Schema:      dataSchema,
+		ReadContext: dataSourceCustomerRead,
+	}
+}
+
+func dataSourceCustomerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	var customer *squareapi.Customer
+
+	if id := d.Get("id").(string); id != "" {
+		var err error
+
+		customer, err = meta.api.RetrieveCustomer(ctx, id)
+		if err != nil {
+			if isNotFound(err) {
+				return diag.Errorf("no customer found with id %s", id)
+			}
+
+			return diag.FromErr(fmt.Errorf("error making network call to retrieve customer: %w", err))
+		}
+	} else {
+		var err error
+
+		customer, err = findCustomer(ctx, meta, &squareapi.CustomerFilter{
+			EmailAddress: d.Get("email_address").(string),
+			PhoneNumber:  d.Get("phone_number").(string),
+			ReferenceID:  d.Get("reference_id").(string),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := customerObjectToResource(customer, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// findCustomer looks up the single customer matching filter.
+func findCustomer(ctx context.Context, meta *providerMeta, filter *squareapi.CustomerFilter) (*squareapi.Customer, error) {
+	customers, err := meta.api.SearchCustomers(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error making network call to search customers: %w", err)
+	}
+
+	var description string
+
+	switch {
+	case filter.EmailAddress != "":
+		description = fmt.Sprintf("email address %q", filter.EmailAddress)
+	case filter.PhoneNumber != "":
+		description = fmt.Sprintf("phone number %q", filter.PhoneNumber)
+	default:
+		description = fmt.Sprintf("reference id %q", filter.ReferenceID)
+	}
+
+	switch len(customers) {
+	case 0:
+		return nil, fmt.Errorf("no customer found with %s", description)
+	case 1:
+		return customers[0], nil
+	}
+
+	ids := make([]string, len(customers))
+	for i, c := range customers {
+		ids[i] = c.ID
+	}
+
+	return nil, fmt.Errorf("found %d customers with %s, use the id to pick one: %s", len(customers), description, strings.Join(ids, ", "))
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDataSourceCustomer(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCustomer()
+	ds := dataSourceCustomer()
+
+	customer, diags := testApply(t, r, meta, nil, map[string]interface{}{
+		"given_name":    "Grace",
+		"email_address": "grace@data.example.com",
+		"phone_number":  "+15555550101",
+		"reference_id":  "data-customer-grace",
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating customer: %v", diags)
+	}
+
+	for _, ref := range []string{"data-customer-twin", "data-customer-twin"} {
+		if _, diags := testApply(t, r, meta, nil, map[string]interface{}{"company_name": "twin", "reference_id": ref}); diags.HasError() {
+			t.Fatalf("error creating customer: %v", diags)
+		}
+	}
+
+	tests := map[string]struct {
+		config      map[string]interface{}
+		errContains string
+	}{
+		"by id":           {config: map[string]interface{}{"id": customer.ID}},
+		"by email":        {config: map[string]interface{}{"email_address": "grace@data.example.com"}},
+		"by phone":        {config: map[string]interface{}{"phone_number": "+15555550101"}},
+		"by reference id": {config: map[string]interface{}{"reference_id": "data-customer-grace"}},
+		"missing": {
+			config:      map[string]interface{}{"email_address": "nobody@data.example.com"},
+			errContains: `no customer found with email address "nobody@data.example.com"`,
+		},
+		"ambiguous": {
+			config:      map[string]interface{}{"reference_id": "data-customer-twin"},
+			errContains: `found 2 customers with reference id "data-customer-twin"`,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			state, diags := testReadDataSource(t, ds, meta, test.config)
+
+			if test.errContains != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, test.errContains) {
+					t.Fatalf("expected error containing %q, found %v", test.errContains, diags)
+				}
+
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("error reading customer: %v", diags)
+			}
+
+			if state.ID != customer.ID || state.Attributes["given_name"] != "Grace" || state.Attributes["email_address"] != "grace@data.example.com" {
+				t.Fatalf("unexpected customer %v", state.Attributes)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDataSourceInventoryCount(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	ds := dataSourceInventoryCount()
+
+	variationID, locationID := testInventoryVariation(t, meta, "inventory-count-item")
+
+	if _, diags := testApply(t, resourceInventoryPhysicalCount(), meta, nil, map[string]interface{}{
+		"catalog_object_id": variationID,
+		"location_id":       locationID,
+		"quantity":          "4",
+	}); diags.HasError() {
+		t.Fatalf("error creating physical count: %v", diags)
+	}
+
+	tests := map[string]struct {
+		state    string
+		quantity string
+	}{
+		"in stock": {quantity: "4"},
+		"sold":     {state: "SOLD", quantity: "0"},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]interface{}{
+				"catalog_object_id": variationID,
+				"location_id":       locationID,
+			}
+
+			if test.state != "" {
+				config["state"] = test.state
+			}
+
+			state, diags := testReadDataSource(t, ds, meta, config)
+			if diags.HasError() {
+				t.Fatalf("error reading inventory count: %v", diags)
+			}
+
+			if state.Attributes["quantity"] != test.quantity {
+				t.Fatalf("expected quantity %s, found %s", test.quantity, state.Attributes["quantity"])
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceLocation() *schema.Resource {
+	return &schema.Resource{
+		Schema:      dataSourceCatalogSchema(locationSchema()),
+		ReadContext: dataSourceLocationRead,
+	}
+}
+
+func dataSourceLocationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	var location *squareapi.Location
+
+	if id := d.Get("id").(string); id != "" {
+		var err error
+
+		location, err = meta.api.RetrieveLocation(ctx, id)
+		if err != nil {
+			if isNotFound(err) {
+				return diag.Errorf("no location found with id %s", id)
+			}
+
+			return diag.FromErr(fmt.Errorf("error making network call to retrieve location: %w", err))
+		}
+	} else {
+		var err error
+
+		location, err = findLocationByName(ctx, meta, d.Get("name").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := locationObjectToResource(location, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func findLocationByName(ctx context.Context, meta *providerMeta, name string) (*squareapi.Location, error) {
+	locations, err := meta.api.ListLocations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error making network call to list locations: %w", err)
+	}
+
+	matches := []*squareapi.Location{}
+
+	for _, l := range locations {
+		if l.Name == name {
+			matches = append(matches, l)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no location found with name %q", name)
+	case 1:
+		return matches[0], nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, match := range matches {
+		ids[i] = match.ID
+	}
+
+	return nil, fmt.Errorf("found %d locations with name %q, use the id to pick one: %s", len(matches), name, strings.Join(ids, ", "))
+}
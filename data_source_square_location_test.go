@@ -0,0 +1,56 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const dataSourceLocationBlock = `resource "square_location" "test_data_location" {
+	name = "my-data-location"
+	description = "my data store"
+}
+
+data "square_location" "by_name" {
+	name = square_location.test_data_location.name
+}
+
+data "square_location" "by_id" {
+	id = square_location.test_data_location.id
+}
+
+`
+
+const dataSourceLocationMissingBlock = `data "square_location" "missing" {
+	name = "my-missing-data-location"
+}
+
+`
+
+func TestDataSourceLocation(t *testing.T) {
+	t.Parallel()
+
+	token := testAccToken()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"square": func() (*schema.Provider, error) { return Provider(), nil }, //nolint:unparam
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerBlock(token) + dataSourceLocationBlock,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.square_location.by_name", "id", "square_location.test_data_location", "id"),
+					resource.TestCheckResourceAttr("data.square_location.by_name", "description", "my data store"),
+					resource.TestCheckResourceAttr("data.square_location.by_id", "name", "my-data-location"),
+				),
+			},
+			{
+				Config:      providerBlock(token) + dataSourceLocationMissingBlock,
+				ExpectError: regexp.MustCompile(`no location found with name "my-missing-data-location"`),
+			},
+		},
+	})
+}
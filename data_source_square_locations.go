@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceLocations() *schema.Resource {
+	location := computedSchemaMap(locationSchema())
+	location["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"status": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{locationStatusActive, locationStatusInactive}, false)),
+			},
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"locations": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Resource{Schema: location},
+			},
+		},
+		ReadContext: dataSourceLocationsRead,
+	}
+}
+
+func dataSourceLocationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	locations, err := meta.api.ListLocations(ctx)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to list locations: %w", err))
+	}
+
+	status := d.Get("status").(string)
+
+	ids := []interface{}{}
+	found := []interface{}{}
+
+	for _, l := range locations {
+		if status != "" && l.Status != status {
+			continue
+		}
+
+		ids = append(ids, l.ID)
+		found = append(found, locationToMap(l))
+	}
+
+	id := "locations"
+	if status != "" {
+		id += "-" + status
+	}
+
+	d.SetId(id)
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting ids: %w", err))
+	}
+
+	if err := d.Set("locations", found); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting locations: %w", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const dataSourceLocationsBlock = `resource "square_location" "test_data_locations" {
+	name = "my-data-locations"
+}
+
+data "square_locations" "active" {
+	status = "ACTIVE"
+
+	depends_on = [square_location.test_data_locations]
+}
+
+`
+
+func TestDataSourceLocations(t *testing.T) {
+	t.Parallel()
+
+	token := testAccToken()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"square": func() (*schema.Provider, error) { return Provider(), nil }, //nolint:unparam
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerBlock(token) + dataSourceLocationsBlock,
+				Check:  checkLocationsContain("data.square_locations.active", "square_location.test_data_locations"),
+			},
+		},
+	})
+}
+
+func checkLocationsContain(dataSourceName, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[dataSourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", dataSourceName)
+		}
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		for k, v := range ds.Primary.Attributes {
+			if v == rs.Primary.ID && k != "id" {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("location %s not found in %s", rs.Primary.ID, dataSourceName)
+	}
+}
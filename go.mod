@@ -6,6 +6,7 @@ require (
 	github.com/Houndie/square-go v0.1.1
 	github.com/fatih/color v1.10.0 // indirect
 	github.com/gofrs/uuid v4.0.0+incompatible
+	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.12.0
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/genproto v0.0.0-20201203001206-6486ece9c497 // indirect
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	// icalendarLocalTimeRegexp matches the floating local date-times square uses for time period starts.
+	icalendarLocalTimeRegexp = regexp.MustCompile(`^[0-9]{8}T[0-9]{6}$`)
+	icalendarDurationRegexp  = regexp.MustCompile(`^P([0-9]+W|([0-9]+D)?(T([0-9]+H)?([0-9]+M)?([0-9]+S)?)?)$`)
+	icalendarRRuleFreqs      = map[string]struct{}{"DAILY": {}, "WEEKLY": {}, "MONTHLY": {}, "YEARLY": {}}
+)
+
+// normalizeICalendarEvent trims an event and converts its line endings, so events written with heredocs
+// compare equal to those returned by square.
+func normalizeICalendarEvent(event string) string {
+	return strings.TrimSpace(strings.ReplaceAll(event, "\r\n", "\n"))
+}
+
+func suppressICalendarEventDiff(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeICalendarEvent(old) == normalizeICalendarEvent(new)
+}
+
+// parseICalendarEvent checks that event is a single VEVENT with a start and a duration, along with an
+// optional recurrence rule, as square requires of time periods.  It returns the event's properties.
+func parseICalendarEvent(event string) (map[string]string, error) {
+	lines := strings.Split(normalizeICalendarEvent(event), "\n")
+	if len(lines) < 2 || lines[0] != "BEGIN:VEVENT" || lines[len(lines)-1] != "END:VEVENT" {
+		return nil, fmt.Errorf("event must start with BEGIN:VEVENT and end with END:VEVENT")
+	}
+
+	properties := map[string]string{}
+
+	for _, line := range lines[1 : len(lines)-1] {
+		line = strings.TrimSpace(line)
+
+		i := strings.Index(line, ":")
+		if i < 1 {
+			return nil, fmt.Errorf("invalid event line %q, expected NAME:VALUE", line)
+		}
+
+		// Drop any parameters, such as DTSTART;TZID=America/New_York.
+		name := strings.ToUpper(strings.SplitN(line[:i], ";", 2)[0])
+		if _, ok := properties[name]; ok {
+			return nil, fmt.Errorf("event has more than one %s", name)
+		}
+
+		properties[name] = line[i+1:]
+	}
+
+	start, ok := properties["DTSTART"]
+	if !ok {
+		return nil, fmt.Errorf("event is missing DTSTART")
+	}
+
+	if !icalendarLocalTimeRegexp.MatchString(start) {
+		return nil, fmt.Errorf("invalid DTSTART %q, expected a local time such as 20210701T170000", start)
+	}
+
+	duration, ok := properties["DURATION"]
+	if !ok {
+		return nil, fmt.Errorf("event is missing DURATION")
+	}
+
+	if duration == "P" || duration == "PT" || !icalendarDurationRegexp.MatchString(duration) {
+		return nil, fmt.Errorf("invalid DURATION %q, expected a duration such as PT2H", duration)
+	}
+
+	if rrule, ok := properties["RRULE"]; ok {
+		if err := validateICalendarRRule(rrule); err != nil {
+			return nil, err
+		}
+	}
+
+	return properties, nil
+}
+
+func validateICalendarRRule(rrule string) error {
+	parts := map[string]string{}
+
+	for _, part := range strings.Split(rrule, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return fmt.Errorf("invalid RRULE part %q, expected NAME=VALUE", part)
+		}
+
+		parts[strings.ToUpper(kv[0])] = kv[1]
+	}
+
+	freq, ok := parts["FREQ"]
+	if !ok {
+		return fmt.Errorf("RRULE is missing FREQ")
+	}
+
+	if _, ok := icalendarRRuleFreqs[strings.ToUpper(freq)]; !ok {
+		return fmt.Errorf("invalid RRULE FREQ %q, expected DAILY, WEEKLY, MONTHLY, or YEARLY", freq)
+	}
+
+	return nil
+}
+
+func validateICalendarEvent(i interface{}, path cty.Path) diag.Diagnostics {
+	event, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "expected event to be a string",
+			AttributePath: path,
+		}}
+	}
+
+	if _, err := parseICalendarEvent(event); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid iCalendar event",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
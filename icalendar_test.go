@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestValidateICalendarEvent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		event       string
+		errContains string
+	}{
+		"happy hour": {
+			event: "BEGIN:VEVENT\nDTSTART:20210701T170000\nDURATION:PT2H\nRRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR\nEND:VEVENT",
+		},
+		"heredoc with crlf": {
+			event: "BEGIN:VEVENT\r\nDTSTART:20210701T000000\r\nDURATION:P1D\r\nEND:VEVENT\r\n",
+		},
+		"week duration": {
+			event: "BEGIN:VEVENT\nDTSTART:20210701T000000\nDURATION:P1W\nEND:VEVENT",
+		},
+		"not an event": {
+			event:       "DTSTART:20210701T170000\nDURATION:PT2H",
+			errContains: "BEGIN:VEVENT",
+		},
+		"missing start": {
+			event:       "BEGIN:VEVENT\nDURATION:PT2H\nEND:VEVENT",
+			errContains: "missing DTSTART",
+		},
+		"date start": {
+			event:       "BEGIN:VEVENT\nDTSTART:2021-07-01\nDURATION:PT2H\nEND:VEVENT",
+			errContains: "invalid DTSTART",
+		},
+		"missing duration": {
+			event:       "BEGIN:VEVENT\nDTSTART:20210701T170000\nEND:VEVENT",
+			errContains: "missing DURATION",
+		},
+		"empty duration": {
+			event:       "BEGIN:VEVENT\nDTSTART:20210701T170000\nDURATION:PT\nEND:VEVENT",
+			errContains: "invalid DURATION",
+		},
+		"hours without time": {
+			event:       "BEGIN:VEVENT\nDTSTART:20210701T170000\nDURATION:P2H\nEND:VEVENT",
+			errContains: "invalid DURATION",
+		},
+		"rrule without freq": {
+			event:       "BEGIN:VEVENT\nDTSTART:20210701T170000\nDURATION:PT2H\nRRULE:BYDAY=MO\nEND:VEVENT",
+			errContains: "missing FREQ",
+		},
+		"rrule with unknown freq": {
+			event:       "BEGIN:VEVENT\nDTSTART:20210701T170000\nDURATION:PT2H\nRRULE:FREQ=HOURLY\nEND:VEVENT",
+			errContains: "invalid RRULE FREQ",
+		},
+		"duplicate property": {
+			event:       "BEGIN:VEVENT\nDTSTART:20210701T170000\nDTSTART:20210702T170000\nDURATION:PT2H\nEND:VEVENT",
+			errContains: "more than one DTSTART",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateICalendarEvent(test.event, cty.Path{})
+
+			switch {
+			case test.errContains == "" && diags.HasError():
+				t.Fatalf("unexpected error: %v", diags)
+			case test.errContains != "" && (!diags.HasError() || !strings.Contains(diags[0].Detail, test.errContains)):
+				t.Fatalf("expected error containing %q, found %v", test.errContains, diags)
+			}
+		})
+	}
+}
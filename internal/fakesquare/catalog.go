@@ -0,0 +1,786 @@
+package fakesquare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	catalogPageSize        = 100
+	catalogMaxBatchObjects = 1000
+	catalogMaxTotalObjects = 10000
+	catalogMaxDeleteIDs    = 200
+)
+
+// catalogObject is a catalog object in its json form.  Objects are kept as generic json so that the fake
+// server doesn't depend on which object types the client library knows how to decode.
+type catalogObject = map[string]interface{}
+
+type idMapping struct {
+	ClientObjectID string `json:"client_object_id"`
+	ObjectID       string `json:"object_id"`
+}
+
+// nestedObjects describes catalog object types that carry child objects, such as an item's variations.
+type nestedObjects struct {
+	listKey   string
+	parentKey string
+}
+
+var catalogChildren = map[string]nestedObjects{
+	"ITEM":          {listKey: "variations", parentKey: "item_id"},
+	"MODIFIER_LIST": {listKey: "modifiers", parentKey: "modifier_list_id"},
+	"ITEM_OPTION":   {listKey: "values", parentKey: "item_option_id"},
+}
+
+// catalogDataDefaults are the values square fills in for data fields that aren't sent.
+var catalogDataDefaults = map[string]map[string]interface{}{
+	"ITEM":           {"product_type": "REGULAR", "visibility": "PRIVATE"},
+	"ITEM_VARIATION": {"sellable": true, "stockable": true},
+}
+
+type catalogStore struct {
+	objects map[string]catalogObject
+	order   []string
+	parents map[string]string
+	version int64
+}
+
+func newCatalogStore() *catalogStore {
+	return &catalogStore{
+		objects: map[string]catalogObject{},
+		parents: map[string]string{},
+		version: 1600000000000,
+	}
+}
+
+// catalogDataKey returns the key holding the type specific data for a catalog object type.
+func catalogDataKey(objectType string) string {
+	if objectType == "ITEM_OPTION_VAL" {
+		return "item_option_value_data"
+	}
+
+	return strings.ToLower(objectType) + "_data"
+}
+
+func objectType(o catalogObject) string {
+	t, _ := o["type"].(string)
+	return t
+}
+
+func objectID(o catalogObject) string {
+	id, _ := o["id"].(string)
+	return id
+}
+
+func objectData(o catalogObject) map[string]interface{} {
+	data, _ := o[catalogDataKey(objectType(o))].(map[string]interface{})
+	return data
+}
+
+// children returns the nested child objects of a catalog object.
+func children(o catalogObject) []catalogObject {
+	nested, ok := catalogChildren[objectType(o)]
+	if !ok {
+		return nil
+	}
+
+	data := objectData(o)
+	if data == nil {
+		return nil
+	}
+
+	list, _ := data[nested.listKey].([]interface{})
+	result := make([]catalogObject, 0, len(list))
+
+	for _, c := range list {
+		if child, ok := c.(map[string]interface{}); ok {
+			result = append(result, child)
+		}
+	}
+
+	return result
+}
+
+func copyObject(o catalogObject) catalogObject {
+	b, err := json.Marshal(o)
+	if err != nil {
+		panic(fmt.Sprintf("error copying catalog object: %v", err))
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(b)))
+	decoder.UseNumber()
+
+	c := catalogObject{}
+	if err := decoder.Decode(&c); err != nil {
+		panic(fmt.Sprintf("error copying catalog object: %v", err))
+	}
+
+	return c
+}
+
+// lookup finds a catalog object by id, whether it's a top level object or nested inside of one.
+func (c *catalogStore) lookup(id string) catalogObject {
+	if o, ok := c.objects[id]; ok {
+		return o
+	}
+
+	parentID, ok := c.parents[id]
+	if !ok {
+		return nil
+	}
+
+	for _, child := range children(c.objects[parentID]) {
+		if objectID(child) == id {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// all returns every stored object, with nested objects directly following their parents.
+func (c *catalogStore) all() []catalogObject {
+	result := []catalogObject{}
+
+	for _, id := range c.order {
+		o := c.objects[id]
+		result = append(result, o)
+		result = append(result, children(o)...)
+	}
+
+	return result
+}
+
+// replaceTemporaryIDs swaps every string matching a temporary id for its permanent id.
+func replaceTemporaryIDs(v interface{}, mappings map[string]string) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, inner := range t {
+			t[k] = replaceTemporaryIDs(inner, mappings)
+		}
+
+		return t
+	case []interface{}:
+		for i, inner := range t {
+			t[i] = replaceTemporaryIDs(inner, mappings)
+		}
+
+		return t
+	case string:
+		if id, ok := mappings[t]; ok {
+			return id
+		}
+	}
+
+	return v
+}
+
+type upsertError struct {
+	status int
+	err    *squareError
+}
+
+// validateUpsert checks that a set of objects may be upserted, without making any changes.
+func (c *catalogStore) validateUpsert(objects []catalogObject) *upsertError {
+	for _, o := range objects {
+		id := objectID(o)
+		if id == "" {
+			return &upsertError{http.StatusBadRequest, &squareError{categoryInvalidRequest, codeBadRequest, "catalog object id is required", "id"}}
+		}
+
+		if objectType(o) == "" {
+			return &upsertError{http.StatusBadRequest, &squareError{categoryInvalidRequest, codeBadRequest, "catalog object type is required", "type"}}
+		}
+
+		if objectData(o) == nil {
+			return &upsertError{http.StatusBadRequest, &squareError{categoryInvalidRequest, codeBadRequest, fmt.Sprintf("object %s is missing %s", id, catalogDataKey(objectType(o))), ""}}
+		}
+
+		if err := c.validateItemOptionValues(o); err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(id, "#") {
+			continue
+		}
+
+		existing, ok := c.objects[id]
+		if !ok {
+			return &upsertError{http.StatusNotFound, &squareError{categoryInvalidRequest, codeNotFound, fmt.Sprintf("Object with id %s not found", id), ""}}
+		}
+
+		if objectType(existing) != objectType(o) {
+			return &upsertError{http.StatusBadRequest, &squareError{categoryInvalidRequest, codeInvalidValue, fmt.Sprintf("object %s cannot change type from %s to %s", id, objectType(existing), objectType(o)), "type"}}
+		}
+
+		if version := toInt64(o["version"]); version != 0 && version != toInt64(existing["version"]) {
+			return &upsertError{http.StatusBadRequest, &squareError{categoryInvalidRequest, codeVersionMismatch, fmt.Sprintf("Object version does not match for object: %s", id), ""}}
+		}
+	}
+
+	return nil
+}
+
+// validateItemOptionValues checks that the option values picked by an item's variations belong to the
+// options they're picked for.
+func (c *catalogStore) validateItemOptionValues(o catalogObject) *upsertError {
+	for _, child := range children(o) {
+		values, _ := objectData(child)["item_option_values"].([]interface{})
+
+		for _, v := range values {
+			value, _ := v.(map[string]interface{})
+			optionID, _ := value["item_option_id"].(string)
+			valueID, _ := value["item_option_value_id"].(string)
+
+			if c.parents[valueID] != optionID {
+				return &upsertError{http.StatusBadRequest, &squareError{categoryInvalidRequest, codeInvalidValue, fmt.Sprintf("item option value %s does not belong to item option %s", valueID, optionID), "item_option_values"}}
+			}
+		}
+	}
+
+	return nil
+}
+
+// upsert stores a set of objects, assigning permanent ids to temporary ones, as a single catalog version.
+// validateUpsert must be called first.
+func (c *catalogStore) upsert(objects []catalogObject, newID func() string) ([]catalogObject, []*idMapping) {
+	mappings := map[string]string{}
+	idMappings := []*idMapping{}
+
+	assign := func(o catalogObject) {
+		id := objectID(o)
+		if !strings.HasPrefix(id, "#") {
+			return
+		}
+
+		if _, ok := mappings[id]; !ok {
+			mappings[id] = newID()
+			idMappings = append(idMappings, &idMapping{ClientObjectID: id, ObjectID: mappings[id]})
+		}
+	}
+
+	for _, o := range objects {
+		assign(o)
+
+		for _, child := range children(o) {
+			assign(child)
+		}
+	}
+
+	c.version++
+	updatedAt := now()
+	result := make([]catalogObject, len(objects))
+
+	for i, o := range objects {
+		o = copyObject(o)
+		replaceTemporaryIDs(o, mappings)
+
+		stamp := func(o catalogObject) {
+			o["version"] = c.version
+			o["updated_at"] = updatedAt
+			o["is_deleted"] = false
+
+			// Square treats a missing present_at_all_locations as true.
+			if _, ok := o["present_at_all_locations"]; !ok {
+				o["present_at_all_locations"] = true
+			}
+
+			if data := objectData(o); data != nil {
+				for k, v := range catalogDataDefaults[objectType(o)] {
+					if _, ok := data[k]; !ok {
+						data[k] = v
+					}
+				}
+			}
+		}
+
+		stamp(o)
+
+		id := objectID(o)
+		nested, hasChildren := catalogChildren[objectType(o)]
+
+		if existing, ok := c.objects[id]; ok {
+			for _, child := range children(existing) {
+				delete(c.parents, objectID(child))
+			}
+		} else {
+			c.order = append(c.order, id)
+		}
+
+		for _, child := range children(o) {
+			stamp(child)
+
+			if hasChildren {
+				childData := objectData(child)
+				if childData != nil {
+					childData[nested.parentKey] = id
+				}
+			}
+
+			c.parents[objectID(child)] = id
+		}
+
+		c.objects[id] = o
+		result[i] = copyObject(o)
+	}
+
+	return result, idMappings
+}
+
+// delete removes an object, along with any nested objects, returning every deleted id.
+func (c *catalogStore) delete(id string) []string {
+	if o, ok := c.objects[id]; ok {
+		deleted := []string{id}
+
+		for _, child := range children(o) {
+			delete(c.parents, objectID(child))
+			deleted = append(deleted, objectID(child))
+		}
+
+		delete(c.objects, id)
+
+		for i, orderID := range c.order {
+			if orderID == id {
+				c.order = append(c.order[:i], c.order[i+1:]...)
+				break
+			}
+		}
+
+		return deleted
+	}
+
+	parentID, ok := c.parents[id]
+	if !ok {
+		return nil
+	}
+
+	parent := c.objects[parentID]
+	nested := catalogChildren[objectType(parent)]
+	data := objectData(parent)
+	list, _ := data[nested.listKey].([]interface{})
+	kept := []interface{}{}
+
+	for _, child := range list {
+		if m, ok := child.(map[string]interface{}); ok && objectID(m) == id {
+			continue
+		}
+
+		kept = append(kept, child)
+	}
+
+	data[nested.listKey] = kept
+	c.version++
+	parent["version"] = c.version
+
+	delete(c.parents, id)
+
+	return []string{id}
+}
+
+func (s *Server) registerCatalog(mux *http.ServeMux) {
+	mux.HandleFunc("/v2/catalog/object", s.handleUpsertCatalogObject)
+	mux.HandleFunc("/v2/catalog/object/", s.handleCatalogObject)
+	mux.HandleFunc("/v2/catalog/list", s.handleListCatalog)
+	mux.HandleFunc("/v2/catalog/batch-upsert", s.handleBatchUpsertCatalogObjects)
+	mux.HandleFunc("/v2/catalog/batch-delete", s.handleBatchDeleteCatalogObjects)
+	mux.HandleFunc("/v2/catalog/batch-retrieve", s.handleBatchRetrieveCatalogObjects)
+	mux.HandleFunc("/v2/catalog/search", s.handleSearchCatalogObjects)
+}
+
+func (s *Server) handleUpsertCatalogObject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	req := struct {
+		IdempotencyKey string        `json:"idempotency_key"`
+		Object         catalogObject `json:"object"`
+	}{}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+		return
+	}
+
+	if req.Object == nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, "object is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.idempotent(w, req.IdempotencyKey, func() (int, interface{}) {
+		if err := s.catalog.validateUpsert([]catalogObject{req.Object}); err != nil {
+			return err.status, map[string]interface{}{"errors": []*squareError{err.err}}
+		}
+
+		objects, mappings := s.catalog.upsert([]catalogObject{req.Object}, s.newID)
+
+		return http.StatusOK, map[string]interface{}{
+			"catalog_object": objects[0],
+			"id_mappings":    mappings,
+		}
+	})
+}
+
+func (s *Server) handleCatalogObject(w http.ResponseWriter, r *http.Request) {
+	id := pathID(r, "/v2/catalog/object/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		o := s.catalog.lookup(id)
+		if o == nil {
+			writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("Object with id %s not found", id))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"object": copyObject(o),
+		})
+	case http.MethodDelete:
+		deleted := s.catalog.delete(id)
+		if deleted == nil {
+			writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("Object with id %s not found", id))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"deleted_object_ids": deleted,
+			"deleted_at":         now(),
+		})
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// page returns one page of objects, starting at the offset encoded in cursor.
+func page(objects []catalogObject, cursor string, limit int) ([]catalogObject, string, error) {
+	start := 0
+
+	if cursor != "" {
+		var err error
+
+		start, err = strconv.Atoi(cursor)
+		if err != nil || start < 0 || start > len(objects) {
+			return nil, "", fmt.Errorf("invalid cursor %s", cursor)
+		}
+	}
+
+	end := start + limit
+	if end >= len(objects) {
+		return objects[start:], "", nil
+	}
+
+	return objects[start:end], strconv.Itoa(end), nil
+}
+
+func (s *Server) handleListCatalog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+
+	types := map[string]bool{}
+
+	if t := r.URL.Query().Get("types"); t != "" {
+		for _, oneType := range strings.Split(t, ",") {
+			types[strings.TrimSpace(oneType)] = true
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filtered := []catalogObject{}
+
+	for _, o := range s.catalog.all() {
+		if len(types) == 0 {
+			// With no types given, Square only lists top level objects.
+			if _, ok := s.catalog.objects[objectID(o)]; !ok {
+				continue
+			}
+		} else if !types[objectType(o)] {
+			continue
+		}
+
+		filtered = append(filtered, copyObject(o))
+	}
+
+	objects, cursor, err := page(filtered, r.URL.Query().Get("cursor"), catalogPageSize)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, "INVALID_CURSOR", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"objects": objects,
+		"cursor":  cursor,
+	})
+}
+
+func (s *Server) handleBatchUpsertCatalogObjects(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	req := struct {
+		IdempotencyKey string `json:"idempotency_key"`
+		Batches        []struct {
+			Objects []catalogObject `json:"objects"`
+		} `json:"batches"`
+	}{}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.idempotent(w, req.IdempotencyKey, func() (int, interface{}) {
+		total := 0
+
+		for _, batch := range req.Batches {
+			count := 0
+			for _, o := range batch.Objects {
+				count += 1 + len(children(o))
+			}
+
+			if count > catalogMaxBatchObjects {
+				return http.StatusBadRequest, errorBody(categoryInvalidRequest, "ARRAY_LENGTH_TOO_LONG", fmt.Sprintf("a batch may contain at most %d objects", catalogMaxBatchObjects))
+			}
+
+			total += count
+		}
+
+		if total > catalogMaxTotalObjects {
+			return http.StatusBadRequest, errorBody(categoryInvalidRequest, "ARRAY_LENGTH_TOO_LONG", fmt.Sprintf("a request may contain at most %d objects", catalogMaxTotalObjects))
+		}
+
+		// Like square, each batch is committed on its own, so a failed batch doesn't stop the others.
+		var (
+			objects  = []catalogObject{}
+			mappings = []*idMapping{}
+			errs     = []*squareError{}
+			status   = http.StatusOK
+		)
+
+		for _, batch := range req.Batches {
+			if err := s.catalog.validateUpsert(batch.Objects); err != nil {
+				errs = append(errs, err.err)
+				status = err.status
+
+				continue
+			}
+
+			o, m := s.catalog.upsert(batch.Objects, s.newID)
+			objects = append(objects, o...)
+			mappings = append(mappings, m...)
+		}
+
+		if len(errs) == 0 {
+			return http.StatusOK, map[string]interface{}{
+				"objects":     objects,
+				"id_mappings": mappings,
+				"updated_at":  now(),
+			}
+		}
+
+		if len(objects) == 0 {
+			return status, map[string]interface{}{"errors": errs}
+		}
+
+		return http.StatusOK, map[string]interface{}{
+			"objects":     objects,
+			"id_mappings": mappings,
+			"errors":      errs,
+			"updated_at":  now(),
+		}
+	})
+}
+
+func (s *Server) handleBatchDeleteCatalogObjects(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	req := struct {
+		ObjectIDs []string `json:"object_ids"`
+	}{}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+		return
+	}
+
+	if len(req.ObjectIDs) > catalogMaxDeleteIDs {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, "ARRAY_LENGTH_TOO_LONG", fmt.Sprintf("a request may delete at most %d objects", catalogMaxDeleteIDs))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := []string{}
+	for _, id := range req.ObjectIDs {
+		deleted = append(deleted, s.catalog.delete(id)...)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"deleted_object_ids": deleted,
+		"deleted_at":         now(),
+	})
+}
+
+func (s *Server) handleBatchRetrieveCatalogObjects(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	req := struct {
+		ObjectIDs []string `json:"object_ids"`
+	}{}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	objects := []catalogObject{}
+
+	for _, id := range req.ObjectIDs {
+		if o := s.catalog.lookup(id); o != nil {
+			objects = append(objects, copyObject(o))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"objects": objects,
+	})
+}
+
+type catalogQuery struct {
+	ExactQuery *struct {
+		AttributeName  string `json:"attribute_name"`
+		AttributeValue string `json:"attribute_value"`
+	} `json:"exact_query"`
+	PrefixQuery *struct {
+		AttributeName   string `json:"attribute_name"`
+		AttributePrefix string `json:"attribute_prefix"`
+	} `json:"prefix_query"`
+	TextQuery *struct {
+		Keywords []string `json:"keywords"`
+	} `json:"text_query"`
+}
+
+func attribute(o catalogObject, name string) string {
+	data := objectData(o)
+	if data == nil {
+		return ""
+	}
+
+	v, ok := data[name]
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprint(v)
+}
+
+func (q *catalogQuery) matches(o catalogObject) bool {
+	if q == nil {
+		return true
+	}
+
+	if q.ExactQuery != nil && !strings.EqualFold(attribute(o, q.ExactQuery.AttributeName), q.ExactQuery.AttributeValue) {
+		return false
+	}
+
+	if q.PrefixQuery != nil && !strings.HasPrefix(strings.ToLower(attribute(o, q.PrefixQuery.AttributeName)), strings.ToLower(q.PrefixQuery.AttributePrefix)) {
+		return false
+	}
+
+	if q.TextQuery != nil {
+		text := strings.ToLower(attribute(o, "name") + " " + attribute(o, "description"))
+
+		for _, keyword := range q.TextQuery.Keywords {
+			if !strings.Contains(text, strings.ToLower(keyword)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (s *Server) handleSearchCatalogObjects(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	req := struct {
+		ObjectTypes []string      `json:"object_types"`
+		Query       *catalogQuery `json:"query"`
+		Limit       int           `json:"limit"`
+		Cursor      string        `json:"cursor"`
+	}{}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+		return
+	}
+
+	types := map[string]bool{}
+	for _, t := range req.ObjectTypes {
+		types[t] = true
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > catalogPageSize {
+		limit = catalogPageSize
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matches := []catalogObject{}
+
+	for _, o := range s.catalog.all() {
+		if len(types) != 0 && !types[objectType(o)] {
+			continue
+		}
+
+		if req.Query.matches(o) {
+			matches = append(matches, copyObject(o))
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return objectID(matches[i]) < objectID(matches[j])
+	})
+
+	objects, cursor, err := page(matches, req.Cursor, limit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, "INVALID_CURSOR", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"objects":     objects,
+		"cursor":      cursor,
+		"latest_time": now(),
+	})
+}
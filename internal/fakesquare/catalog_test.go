@@ -0,0 +1,310 @@
+package fakesquare
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testToken = "token"
+
+func do(t *testing.T, s *Server, method, path string, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+
+	var reqBody *bytes.Buffer
+
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reqBody = bytes.NewBuffer(b)
+	} else {
+		reqBody = &bytes.Buffer{}
+	}
+
+	req, err := http.NewRequest(method, s.URL+path, reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+testToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	res := map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.StatusCode, res
+}
+
+func errorCode(res map[string]interface{}) string {
+	errs, _ := res["errors"].([]interface{})
+	if len(errs) == 0 {
+		return ""
+	}
+
+	code, _ := errs[0].(map[string]interface{})["code"].(string)
+
+	return code
+}
+
+func testItem(id, variationID string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":   id,
+		"type": "ITEM",
+		"item_data": map[string]interface{}{
+			"name": "item",
+			"variations": []interface{}{
+				map[string]interface{}{
+					"id":   variationID,
+					"type": "ITEM_VARIATION",
+					"item_variation_data": map[string]interface{}{
+						"item_id":      id,
+						"name":         "variation",
+						"pricing_type": "VARIABLE_PRICING",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer("other-token")
+	defer s.Close()
+
+	status, res := do(t, s, http.MethodGet, "/catalog/list", nil)
+	if status != http.StatusUnauthorized || errorCode(res) != codeUnauthorized {
+		t.Fatalf("expected unauthorized, found %d %v", status, res)
+	}
+}
+
+func TestUpsertResolvesTemporaryIDs(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer(testToken)
+	defer s.Close()
+
+	status, res := do(t, s, http.MethodPost, "/catalog/object", map[string]interface{}{
+		"idempotency_key": "key",
+		"object":          testItem("#item", "#variation"),
+	})
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d: %v", status, res)
+	}
+
+	object := res["catalog_object"].(map[string]interface{})
+	id := object["id"].(string)
+
+	if strings.HasPrefix(id, "#") {
+		t.Fatalf("temporary id not replaced")
+	}
+
+	variation := object["item_data"].(map[string]interface{})["variations"].([]interface{})[0].(map[string]interface{})
+	if strings.HasPrefix(variation["id"].(string), "#") {
+		t.Fatalf("temporary variation id not replaced")
+	}
+
+	if itemID := variation["item_variation_data"].(map[string]interface{})["item_id"]; itemID != id {
+		t.Fatalf("variation item id %v does not reference item %s", itemID, id)
+	}
+
+	if mappings := res["id_mappings"].([]interface{}); len(mappings) != 2 {
+		t.Fatalf("expected 2 id mappings, found %d", len(mappings))
+	}
+
+	status, res = do(t, s, http.MethodGet, "/catalog/object/"+variation["id"].(string), nil)
+	if status != http.StatusOK || res["object"].(map[string]interface{})["type"] != "ITEM_VARIATION" {
+		t.Fatalf("unable to retrieve nested variation: %d %v", status, res)
+	}
+
+	// Replaying the idempotency key returns the original object instead of creating a new one.
+	_, res = do(t, s, http.MethodPost, "/catalog/object", map[string]interface{}{
+		"idempotency_key": "key",
+		"object":          testItem("#item", "#variation"),
+	})
+	if res["catalog_object"].(map[string]interface{})["id"] != id {
+		t.Fatalf("idempotency key was not honored")
+	}
+}
+
+func TestUpsertVersionMismatch(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer(testToken)
+	defer s.Close()
+
+	_, res := do(t, s, http.MethodPost, "/catalog/object", map[string]interface{}{
+		"object": testItem("#item", "#variation"),
+	})
+	object := res["catalog_object"].(map[string]interface{})
+	id := object["id"].(string)
+	version := object["version"]
+
+	update := testItem(id, "#variation2")
+	update["version"] = version
+
+	status, res := do(t, s, http.MethodPost, "/catalog/object", map[string]interface{}{
+		"object": update,
+	})
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d: %v", status, res)
+	}
+
+	if res["catalog_object"].(map[string]interface{})["version"] == version {
+		t.Fatalf("version was not incremented")
+	}
+
+	status, res = do(t, s, http.MethodPost, "/catalog/object", map[string]interface{}{
+		"object": update,
+	})
+	if status != http.StatusBadRequest || errorCode(res) != codeVersionMismatch {
+		t.Fatalf("expected version mismatch, found %d %v", status, res)
+	}
+}
+
+func TestDeleteAndNotFound(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer(testToken)
+	defer s.Close()
+
+	_, res := do(t, s, http.MethodPost, "/catalog/object", map[string]interface{}{
+		"object": testItem("#item", "#variation"),
+	})
+	id := res["catalog_object"].(map[string]interface{})["id"].(string)
+
+	status, res := do(t, s, http.MethodDelete, "/catalog/object/"+id, nil)
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d: %v", status, res)
+	}
+
+	if deleted := res["deleted_object_ids"].([]interface{}); len(deleted) != 2 {
+		t.Fatalf("expected item and variation to be deleted, found %v", deleted)
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		status, res = do(t, s, method, "/catalog/object/"+id, nil)
+		if status != http.StatusNotFound || errorCode(res) != codeNotFound {
+			t.Fatalf("expected not found, found %d %v", status, res)
+		}
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer(testToken)
+	defer s.Close()
+
+	const count = catalogPageSize + 5
+
+	objects := make([]interface{}, count)
+	for i := range objects {
+		objects[i] = map[string]interface{}{
+			"id":            "#category" + string(rune('a'+i%26)) + string(rune('a'+i/26)),
+			"type":          "CATEGORY",
+			"category_data": map[string]interface{}{"name": "category"},
+		}
+	}
+
+	status, res := do(t, s, http.MethodPost, "/catalog/batch-upsert", map[string]interface{}{
+		"batches": []interface{}{map[string]interface{}{"objects": objects}},
+	})
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d: %v", status, res)
+	}
+
+	found := 0
+	cursor := ""
+
+	for {
+		path := "/catalog/list?types=CATEGORY"
+		if cursor != "" {
+			path += "&cursor=" + cursor
+		}
+
+		_, res := do(t, s, http.MethodGet, path, nil)
+		found += len(res["objects"].([]interface{}))
+
+		cursor, _ = res["cursor"].(string)
+		if cursor == "" {
+			break
+		}
+	}
+
+	if found != count {
+		t.Fatalf("expected %d objects, found %d", count, found)
+	}
+}
+
+func TestBatchDeleteLimit(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer(testToken)
+	defer s.Close()
+
+	ids := make([]string, catalogMaxDeleteIDs+1)
+	for i := range ids {
+		ids[i] = "id"
+	}
+
+	status, res := do(t, s, http.MethodPost, "/catalog/batch-delete", map[string]interface{}{"object_ids": ids})
+	if status != http.StatusBadRequest || errorCode(res) != "ARRAY_LENGTH_TOO_LONG" {
+		t.Fatalf("expected more than %d ids to be rejected, found %d %v", catalogMaxDeleteIDs, status, res)
+	}
+
+	status, res = do(t, s, http.MethodPost, "/catalog/batch-delete", map[string]interface{}{"object_ids": ids[1:]})
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d: %v", status, res)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer(testToken)
+	defer s.Close()
+
+	do(t, s, http.MethodPost, "/catalog/object", map[string]interface{}{
+		"object": testItem("#item", "#variation"),
+	})
+
+	_, res := do(t, s, http.MethodPost, "/catalog/search", map[string]interface{}{
+		"object_types": []string{"ITEM"},
+		"query": map[string]interface{}{
+			"exact_query": map[string]interface{}{
+				"attribute_name":  "name",
+				"attribute_value": "item",
+			},
+		},
+	})
+	if objects := res["objects"].([]interface{}); len(objects) != 1 {
+		t.Fatalf("expected 1 search result, found %d", len(objects))
+	}
+
+	_, res = do(t, s, http.MethodPost, "/catalog/search", map[string]interface{}{
+		"object_types": []string{"ITEM"},
+		"query": map[string]interface{}{
+			"exact_query": map[string]interface{}{
+				"attribute_name":  "name",
+				"attribute_value": "missing",
+			},
+		},
+	})
+	if objects := res["objects"].([]interface{}); len(objects) != 0 {
+		t.Fatalf("expected no search results, found %d", len(objects))
+	}
+}
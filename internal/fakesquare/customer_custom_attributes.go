@@ -0,0 +1,340 @@
+package fakesquare
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// customAttributeSchemaPrefix is the start of the $ref of every schema square supports for custom attributes,
+// other than selections.
+const customAttributeSchemaPrefix = "https://developer-production-s.squarecdn.com/schemas/v1/common.json#squareup.common."
+
+// customAttributeSelectionMetaSchema is the $schema of selection schemas, which have no $ref.
+const customAttributeSelectionMetaSchema = "https://developer-production-s.squarecdn.com/meta-schemas/v1/selection.json"
+
+var customAttributeTypes = []string{"String", "Email", "PhoneNumber", "Address", "Date", "Boolean", "Number"}
+
+func (s *Server) registerCustomerCustomAttributes(mux *http.ServeMux) {
+	mux.HandleFunc("/v2/customers/custom-attribute-definitions", s.handleCustomAttributeDefinitions)
+	mux.HandleFunc("/v2/customers/custom-attribute-definitions/", s.handleCustomAttributeDefinition)
+}
+
+// customAttributeType returns the square type a schema refers to, such as String or Selection.
+func customAttributeType(schema jsonObject) (string, error) {
+	if schema["$schema"] == customAttributeSelectionMetaSchema {
+		if schema["type"] != "array" {
+			return "", fmt.Errorf("selection schema must have type array")
+		}
+
+		return "Selection", nil
+	}
+
+	ref, _ := schema["$ref"].(string)
+
+	for _, t := range customAttributeTypes {
+		if ref == customAttributeSchemaPrefix+t {
+			return t, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported custom attribute schema %q", ref)
+}
+
+// normalizeCustomAttributeSchema validates a schema, giving each new option of a selection schema an id the way
+// square does.  Options keep the ids they had in previous, if any.  It must be called with the server lock held.
+func (s *Server) normalizeCustomAttributeSchema(schema, previous jsonObject) error {
+	t, err := customAttributeType(schema)
+	if err != nil {
+		return err
+	}
+
+	if previous != nil {
+		if previousType, _ := customAttributeType(previous); previousType != t {
+			return fmt.Errorf("custom attribute schema type can't be changed from %s to %s", previousType, t)
+		}
+	}
+
+	if t != "Selection" {
+		return nil
+	}
+
+	items, _ := schema["items"].(map[string]interface{})
+	names, _ := items["names"].([]interface{})
+
+	if len(names) == 0 {
+		return fmt.Errorf("selection schema must have at least one name")
+	}
+
+	previousIDs := map[string]interface{}{}
+
+	if previous != nil {
+		previousItems, _ := previous["items"].(map[string]interface{})
+		previousNames, _ := previousItems["names"].([]interface{})
+		previousEnum, _ := previousItems["enum"].([]interface{})
+
+		for i, name := range previousNames {
+			if i < len(previousEnum) {
+				previousIDs[fmt.Sprint(name)] = previousEnum[i]
+			}
+		}
+	}
+
+	enum := make([]interface{}, len(names))
+
+	for i, name := range names {
+		if id, ok := previousIDs[fmt.Sprint(name)]; ok {
+			enum[i] = id
+			continue
+		}
+
+		enum[i] = s.newID()
+	}
+
+	items["enum"] = enum
+
+	return nil
+}
+
+// validateCustomAttributeValue checks value has the shape the schema's type calls for.
+func validateCustomAttributeValue(schema jsonObject, value interface{}) error {
+	t, err := customAttributeType(schema)
+	if err != nil {
+		return err
+	}
+
+	switch t {
+	case "Boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected a boolean value, found %v", value)
+		}
+	case "Address":
+		if _, ok := value.(map[string]interface{}); !ok {
+			return fmt.Errorf("expected an address value, found %v", value)
+		}
+	case "Selection":
+		selected, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected a list of selected option ids, found %v", value)
+		}
+
+		if maxItems := toInt64(schema["maxItems"]); maxItems > 0 && int64(len(selected)) > maxItems {
+			return fmt.Errorf("at most %d options may be selected, found %d", maxItems, len(selected))
+		}
+
+		items, _ := schema["items"].(map[string]interface{})
+		enum, _ := items["enum"].([]interface{})
+
+		for _, id := range selected {
+			found := false
+
+			for _, option := range enum {
+				if id == option {
+					found = true
+				}
+			}
+
+			if !found {
+				return fmt.Errorf("unknown selection option id %v", id)
+			}
+		}
+	default:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("expected a string value, found %v", value)
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) handleCustomAttributeDefinitions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	req := struct {
+		IdempotencyKey string     `json:"idempotency_key"`
+		Definition     jsonObject `json:"custom_attribute_definition"`
+	}{}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.idempotent(w, req.IdempotencyKey, func() (int, interface{}) {
+		key, _ := req.Definition["key"].(string)
+		if key == "" {
+			return http.StatusBadRequest, errorBody(categoryInvalidRequest, codeBadRequest, "custom attribute definition key is required")
+		}
+
+		if s.customAttributeDefinitions.get(key) != nil {
+			return http.StatusBadRequest, errorBody(categoryInvalidRequest, codeBadRequest, fmt.Sprintf("custom attribute definition with key `%s` already exists", key))
+		}
+
+		schema, _ := req.Definition["schema"].(map[string]interface{})
+		if err := s.normalizeCustomAttributeSchema(schema, nil); err != nil {
+			return http.StatusBadRequest, errorBody(categoryInvalidRequest, codeInvalidValue, err.Error())
+		}
+
+		definition := jsonObject{
+			"visibility": "VISIBILITY_HIDDEN",
+			"created_at": now(),
+			"updated_at": now(),
+		}
+		merge(definition, req.Definition)
+		definition["version"] = 1
+
+		s.customAttributeDefinitions.put(key, definition)
+
+		return http.StatusOK, map[string]interface{}{"custom_attribute_definition": definition}
+	})
+}
+
+func (s *Server) handleCustomAttributeDefinition(w http.ResponseWriter, r *http.Request) {
+	key := pathID(r, "/v2/customers/custom-attribute-definitions/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	definition := s.customAttributeDefinitions.get(key)
+	if definition == nil {
+		writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("Custom attribute definition with key `%s` not found.", key))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"custom_attribute_definition": definition})
+	case http.MethodPut:
+		req := struct {
+			Definition jsonObject `json:"custom_attribute_definition"`
+		}{}
+		if err := decodeBody(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+			return
+		}
+
+		if version, ok := req.Definition["version"]; ok && toInt64(version) != toInt64(definition["version"]) {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeVersionMismatch, fmt.Sprintf("custom attribute definition %s has version %d", key, toInt64(definition["version"])))
+			return
+		}
+
+		if schema, ok := req.Definition["schema"].(map[string]interface{}); ok {
+			previous, _ := definition["schema"].(map[string]interface{})
+			if err := s.normalizeCustomAttributeSchema(schema, previous); err != nil {
+				writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeInvalidValue, err.Error())
+				return
+			}
+		}
+
+		for _, field := range []string{"key", "version", "created_at", "updated_at"} {
+			delete(req.Definition, field)
+		}
+
+		merge(definition, req.Definition)
+		definition["version"] = toInt64(definition["version"]) + 1
+		definition["updated_at"] = now()
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"custom_attribute_definition": definition})
+	case http.MethodDelete:
+		s.customAttributeDefinitions.remove(key)
+
+		// Deleting a definition deletes its value on every customer.
+		for _, attribute := range s.customAttributes.all() {
+			if attribute["key"] == key {
+				s.customAttributes.remove(customAttributeID(attribute["customer_id"].(string), key))
+			}
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func customAttributeID(customerID, key string) string {
+	return customerID + "/" + key
+}
+
+// handleCustomAttribute serves /v2/customers/{customer_id}/custom-attributes/{key}.  It must be called with the
+// server lock held.
+func (s *Server) handleCustomAttribute(w http.ResponseWriter, r *http.Request, customerID, key string) {
+	definition := s.customAttributeDefinitions.get(key)
+	if definition == nil {
+		writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("Custom attribute definition with key `%s` not found.", key))
+		return
+	}
+
+	id := customAttributeID(customerID, key)
+	attribute := s.customAttributes.get(id)
+
+	switch r.Method {
+	case http.MethodGet, http.MethodDelete:
+		if attribute == nil {
+			writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("Custom attribute with key `%s` not found for customer `%s`.", key, customerID))
+			return
+		}
+
+		if r.Method == http.MethodDelete {
+			s.customAttributes.remove(id)
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"custom_attribute": attribute})
+	case http.MethodPost:
+		req := struct {
+			IdempotencyKey string     `json:"idempotency_key"`
+			Attribute      jsonObject `json:"custom_attribute"`
+		}{}
+		if err := decodeBody(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+			return
+		}
+
+		s.idempotent(w, req.IdempotencyKey, func() (int, interface{}) {
+			schema, _ := definition["schema"].(map[string]interface{})
+			if err := validateCustomAttributeValue(schema, req.Attribute["value"]); err != nil {
+				return http.StatusBadRequest, errorBody(categoryInvalidRequest, codeInvalidValue, err.Error())
+			}
+
+			if attribute == nil {
+				attribute = jsonObject{
+					"key":         key,
+					"customer_id": customerID,
+					"created_at":  now(),
+					"version":     0,
+				}
+			}
+
+			if version, ok := req.Attribute["version"]; ok && toInt64(version) != toInt64(attribute["version"]) {
+				return http.StatusBadRequest, errorBody(categoryInvalidRequest, codeVersionMismatch, fmt.Sprintf("custom attribute %s has version %d", key, toInt64(attribute["version"])))
+			}
+
+			attribute["value"] = req.Attribute["value"]
+			attribute["visibility"] = definition["visibility"]
+			attribute["version"] = toInt64(attribute["version"]) + 1
+			attribute["updated_at"] = now()
+
+			s.customAttributes.put(id, attribute)
+
+			return http.StatusOK, map[string]interface{}{"custom_attribute": attribute}
+		})
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// removeCustomAttributes deletes every custom attribute value of a customer.  It must be called with the server
+// lock held.
+func (s *Server) removeCustomAttributes(customerID string) {
+	for _, attribute := range s.customAttributes.all() {
+		if attribute["customer_id"] == customerID {
+			s.customAttributes.remove(customAttributeID(customerID, attribute["key"].(string)))
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package fakesquare
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// customerIdentityFields are the fields square requires at least one of when creating a customer.
+var customerIdentityFields = []string{"given_name", "family_name", "company_name", "email_address", "phone_number"}
+
+var customerBirthdayRegexp = regexp.MustCompile(`^([0-9]{4}-)?[0-9]{2}-[0-9]{2}$`)
+
+func (s *Server) registerCustomers(mux *http.ServeMux) {
+	mux.HandleFunc("/v2/customers", s.handleCustomers)
+	mux.HandleFunc("/v2/customers/search", s.handleSearchCustomers)
+	mux.HandleFunc("/v2/customers/", s.handleCustomer)
+	mux.HandleFunc("/v2/customers/groups", s.handleCustomerGroups)
+	mux.HandleFunc("/v2/customers/groups/", s.handleCustomerGroup)
+}
+
+// normalizeCustomer validates a customer create or update, filling in the year of a birthday without one the
+// way square does.
+func normalizeCustomer(customer jsonObject) error {
+	if birthday, ok := customer["birthday"].(string); ok {
+		if !customerBirthdayRegexp.MatchString(birthday) {
+			return fmt.Errorf("invalid birthday %s, expected YYYY-MM-DD or MM-DD", birthday)
+		}
+
+		if len(birthday) == len("MM-DD") {
+			customer["birthday"] = "0000-" + birthday
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) handleCustomers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	req := jsonObject{}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, _ := req["idempotency_key"].(string)
+	delete(req, "idempotency_key")
+
+	s.idempotent(w, key, func() (int, interface{}) {
+		identified := false
+
+		for _, field := range customerIdentityFields {
+			if v, _ := req[field].(string); v != "" {
+				identified = true
+			}
+		}
+
+		if !identified {
+			return http.StatusBadRequest, errorBody(categoryInvalidRequest, codeBadRequest, "at least one of given_name, family_name, company_name, email_address, or phone_number is required")
+		}
+
+		if err := normalizeCustomer(req); err != nil {
+			return http.StatusBadRequest, errorBody(categoryInvalidRequest, codeInvalidValue, err.Error())
+		}
+
+		id := s.newID()
+		customer := jsonObject{
+			"created_at": now(),
+			"updated_at": now(),
+			"version":    0,
+		}
+		merge(customer, req)
+		customer["id"] = id
+
+		s.customers.put(id, customer)
+
+		return http.StatusOK, map[string]interface{}{"customer": customer}
+	})
+}
+
+func (s *Server) handleCustomer(w http.ResponseWriter, r *http.Request) {
+	// Memberships are managed at /v2/customers/{customer_id}/groups/{group_id}, and custom attribute values at
+	// /v2/customers/{customer_id}/custom-attributes/{key}.
+	parts := strings.Split(pathID(r, "/v2/customers/"), "/")
+	id := parts[0]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	customer := s.customers.get(id)
+	if customer == nil {
+		writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("Customer with ID `%s` not found.", id))
+		return
+	}
+
+	switch {
+	case len(parts) == 3 && parts[1] == "groups":
+		s.handleCustomerMembership(w, r, customer, parts[2])
+		return
+	case len(parts) == 3 && parts[1] == "custom-attributes":
+		s.handleCustomAttribute(w, r, id, parts[2])
+		return
+	case len(parts) != 1:
+		writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("%s not found", r.URL.Path))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"customer": customer})
+	case http.MethodPut:
+		req := jsonObject{}
+		if err := decodeBody(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+			return
+		}
+
+		if version, ok := req["version"]; ok && toInt64(version) != toInt64(customer["version"]) {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeVersionMismatch, fmt.Sprintf("customer %s has version %d", id, toInt64(customer["version"])))
+			return
+		}
+
+		if err := normalizeCustomer(req); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeInvalidValue, err.Error())
+			return
+		}
+
+		for _, field := range []string{"id", "version", "created_at", "updated_at"} {
+			delete(req, field)
+		}
+
+		merge(customer, req)
+		customer["version"] = toInt64(customer["version"]) + 1
+		customer["updated_at"] = now()
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"customer": customer})
+	case http.MethodDelete:
+		s.customers.remove(id)
+		s.removeCustomAttributes(id)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// handleSearchCustomers supports exact matches on email address, phone number, and reference id.  Results
+// aren't paginated.
+func (s *Server) handleSearchCustomers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	req := struct {
+		Query struct {
+			Filter map[string]struct {
+				Exact string `json:"exact"`
+			} `json:"filter"`
+		} `json:"query"`
+	}{}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	customers := []jsonObject{}
+
+	for _, customer := range s.customers.all() {
+		matches := true
+
+		for field, filter := range req.Query.Filter {
+			if v, _ := customer[field].(string); !strings.EqualFold(v, filter.Exact) {
+				matches = false
+			}
+		}
+
+		if matches {
+			customers = append(customers, customer)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"customers": customers})
+}
+
+func (s *Server) handleCustomerMembership(w http.ResponseWriter, r *http.Request, customer jsonObject, groupID string) {
+	if s.groups.get(groupID) == nil {
+		writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("Customer group with ID `%s` not found.", groupID))
+		return
+	}
+
+	groupIDs := []interface{}{}
+
+	for _, id := range customerGroupIDs(customer) {
+		if id != groupID {
+			groupIDs = append(groupIDs, id)
+		}
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		groupIDs = append(groupIDs, groupID)
+	case http.MethodDelete:
+	default:
+		methodNotAllowed(w)
+		return
+	}
+
+	customer["group_ids"] = groupIDs
+	if len(groupIDs) == 0 {
+		delete(customer, "group_ids")
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+func customerGroupIDs(customer jsonObject) []string {
+	ids := []string{}
+
+	groupIDs, _ := customer["group_ids"].([]interface{})
+	for _, id := range groupIDs {
+		if s, ok := id.(string); ok {
+			ids = append(ids, s)
+		}
+	}
+
+	return ids
+}
+
+func (s *Server) handleCustomerGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"groups": s.groups.all()})
+	case http.MethodPost:
+		req := struct {
+			IdempotencyKey string     `json:"idempotency_key"`
+			Group          jsonObject `json:"group"`
+		}{}
+		if err := decodeBody(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+			return
+		}
+
+		s.idempotent(w, req.IdempotencyKey, func() (int, interface{}) {
+			if name, _ := req.Group["name"].(string); name == "" {
+				return http.StatusBadRequest, errorBody(categoryInvalidRequest, codeBadRequest, "customer group name is required")
+			}
+
+			id := s.newID()
+			group := jsonObject{
+				"created_at": now(),
+				"updated_at": now(),
+			}
+			merge(group, req.Group)
+			group["id"] = id
+
+			s.groups.put(id, group)
+
+			return http.StatusOK, map[string]interface{}{"group": group}
+		})
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) handleCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	id := pathID(r, "/v2/customers/groups/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group := s.groups.get(id)
+	if group == nil {
+		writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("Customer group with ID `%s` not found.", id))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"group": group})
+	case http.MethodPut:
+		req := struct {
+			Group jsonObject `json:"group"`
+		}{}
+		if err := decodeBody(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+			return
+		}
+
+		for _, field := range []string{"id", "created_at", "updated_at"} {
+			delete(req.Group, field)
+		}
+
+		merge(group, req.Group)
+		group["updated_at"] = now()
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"group": group})
+	case http.MethodDelete:
+		s.groups.remove(id)
+
+		// Deleting a group removes every customer from it.
+		for _, customer := range s.customers.all() {
+			groupIDs := []interface{}{}
+
+			for _, gid := range customerGroupIDs(customer) {
+				if gid != id {
+					groupIDs = append(groupIDs, gid)
+				}
+			}
+
+			customer["group_ids"] = groupIDs
+			if len(groupIDs) == 0 {
+				delete(customer, "group_ids")
+			}
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		methodNotAllowed(w)
+	}
+}
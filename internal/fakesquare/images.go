@@ -0,0 +1,151 @@
+package fakesquare
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// imageExtensions are the image content types square accepts, along with the extension of the stored file.
+var imageExtensions = map[string]string{
+	"image/jpeg":  ".jpeg",
+	"image/pjpeg": ".jpeg",
+	"image/png":   ".png",
+	"image/gif":   ".gif",
+}
+
+type createImageRequest struct {
+	IdempotencyKey string        `json:"idempotency_key"`
+	ObjectID       string        `json:"object_id"`
+	Image          catalogObject `json:"image"`
+}
+
+// readCreateImageRequest reads the json request and the image file out of a multipart create image request.
+func readCreateImageRequest(r *http.Request) (*createImageRequest, []byte, string, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("error reading multipart body: %w", err)
+	}
+
+	var (
+		req         *createImageRequest
+		file        []byte
+		contentType string
+	)
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("error reading multipart body: %w", err)
+		}
+
+		switch part.FormName() {
+		case "request":
+			req = &createImageRequest{}
+
+			decoder := json.NewDecoder(part)
+			decoder.UseNumber()
+
+			if err := decoder.Decode(req); err != nil {
+				return nil, nil, "", fmt.Errorf("error decoding request: %w", err)
+			}
+		case "image_file":
+			contentType, _, _ = mime.ParseMediaType(part.Header.Get("Content-Type"))
+
+			file, err = io.ReadAll(part)
+			if err != nil {
+				return nil, nil, "", fmt.Errorf("error reading image file: %w", err)
+			}
+		}
+	}
+
+	if req == nil {
+		return nil, nil, "", errors.New("request is required")
+	}
+
+	if file == nil {
+		return nil, nil, "", errors.New("image_file is required")
+	}
+
+	return req, file, contentType, nil
+}
+
+// attachImage adds an image to a top level catalog object as a new version of that object.
+func (c *catalogStore) attachImage(objectID, imageID string) {
+	o := c.objects[objectID]
+
+	data := objectData(o)
+	if data == nil {
+		return
+	}
+
+	imageIDs, _ := data["image_ids"].([]interface{})
+	data["image_ids"] = append(imageIDs, imageID)
+
+	c.version++
+	o["version"] = c.version
+	o["updated_at"] = now()
+}
+
+func (s *Server) registerImages(mux *http.ServeMux) {
+	mux.HandleFunc("/v2/catalog/images", s.handleCreateCatalogImage)
+}
+
+func (s *Server) handleCreateCatalogImage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	req, file, contentType, err := readCreateImageRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+		return
+	}
+
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeInvalidValue, fmt.Sprintf("unsupported image content type %q", contentType))
+		return
+	}
+
+	if req.Image == nil || objectType(req.Image) != "IMAGE" {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, "an IMAGE object is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.idempotent(w, req.IdempotencyKey, func() (int, interface{}) {
+		if err := s.catalog.validateUpsert([]catalogObject{req.Image}); err != nil {
+			return err.status, map[string]interface{}{"errors": []*squareError{err.err}}
+		}
+
+		if req.ObjectID != "" && s.catalog.objects[req.ObjectID] == nil {
+			return http.StatusNotFound, errorBody(categoryInvalidRequest, codeNotFound, fmt.Sprintf("Object with id %s not found", req.ObjectID))
+		}
+
+		// The url is derived from the file's contents, so that tests can check what was uploaded.
+		objectData(req.Image)["url"] = fmt.Sprintf("https://images.fakesquare.invalid/files/%x/original%s", sha256.Sum256(file), extension)
+
+		images, _ := s.catalog.upsert([]catalogObject{req.Image}, s.newID)
+		image := images[0]
+
+		if req.ObjectID != "" {
+			s.catalog.attachImage(req.ObjectID, objectID(image))
+		}
+
+		return http.StatusOK, map[string]interface{}{
+			"image": image,
+		}
+	})
+}
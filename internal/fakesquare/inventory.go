@@ -0,0 +1,193 @@
+package fakesquare
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+var inventoryQuantityRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,5})?$`)
+
+var inventoryStates = []string{"IN_STOCK", "SOLD", "RETURNED_BY_CUSTOMER", "RESERVED_FOR_SALE", "SOLD_ONLINE", "ORDERED_FROM_VENDOR", "RECEIVED_FROM_VENDOR", "WASTE", "UNLINKED_RETURN", "COMPOSED", "DECOMPOSED", "NONE"}
+
+func (s *Server) registerInventory(mux *http.ServeMux) {
+	mux.HandleFunc("/v2/inventory/changes/batch-create", s.handleBatchChangeInventory)
+	mux.HandleFunc("/v2/inventory/physical-counts/", s.handleInventoryPhysicalCount)
+	mux.HandleFunc("/v2/inventory/counts/batch-retrieve", s.handleBatchRetrieveInventoryCounts)
+}
+
+func inventoryCountID(catalogObjectID, locationID, state string) string {
+	return catalogObjectID + "/" + locationID + "/" + state
+}
+
+// validatePhysicalCount checks a physical count refers to a stored variation and location.  It must be called
+// with the server lock held.
+func (s *Server) validatePhysicalCount(count jsonObject) error {
+	catalogObjectID, _ := count["catalog_object_id"].(string)
+	if o := s.catalog.lookup(catalogObjectID); o == nil || objectType(o) != "ITEM_VARIATION" {
+		return fmt.Errorf("catalog object %s is not an item variation", catalogObjectID)
+	}
+
+	locationID, _ := count["location_id"].(string)
+	if s.locations.get(locationID) == nil {
+		return fmt.Errorf("location %s not found", locationID)
+	}
+
+	state, _ := count["state"].(string)
+
+	found := false
+
+	for _, s := range inventoryStates {
+		if state == s {
+			found = true
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("invalid inventory state %s", state)
+	}
+
+	if quantity, _ := count["quantity"].(string); !inventoryQuantityRegexp.MatchString(quantity) {
+		return fmt.Errorf("invalid quantity %q", quantity)
+	}
+
+	if occurredAt, _ := count["occurred_at"].(string); occurredAt == "" {
+		return fmt.Errorf("occurred_at is required")
+	}
+
+	return nil
+}
+
+func (s *Server) handleBatchChangeInventory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	req := struct {
+		IdempotencyKey string       `json:"idempotency_key"`
+		Changes        []jsonObject `json:"changes"`
+	}{}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.idempotent(w, req.IdempotencyKey, func() (int, interface{}) {
+		if req.IdempotencyKey == "" {
+			return http.StatusBadRequest, errorBody(categoryInvalidRequest, codeBadRequest, "idempotency_key is required")
+		}
+
+		// Every change is validated before any is applied, as batches are atomic.
+		for _, change := range req.Changes {
+			if change["type"] != "PHYSICAL_COUNT" {
+				return http.StatusBadRequest, errorBody(categoryInvalidRequest, codeBadRequest, fmt.Sprintf("unsupported inventory change type %v", change["type"]))
+			}
+
+			count, _ := change["physical_count"].(map[string]interface{})
+			if err := s.validatePhysicalCount(count); err != nil {
+				return http.StatusBadRequest, errorBody(categoryInvalidRequest, codeInvalidValue, err.Error())
+			}
+		}
+
+		changes := []jsonObject{}
+		counts := []jsonObject{}
+
+		for _, change := range req.Changes {
+			count := jsonObject{}
+			merge(count, change["physical_count"].(map[string]interface{}))
+
+			count["id"] = s.newID()
+			count["catalog_object_type"] = "ITEM_VARIATION"
+			count["created_at"] = now()
+
+			s.physicalCounts.put(count["id"].(string), count)
+
+			catalogObjectID, locationID, state := count["catalog_object_id"].(string), count["location_id"].(string), count["state"].(string)
+			calculated := jsonObject{
+				"catalog_object_id":   catalogObjectID,
+				"catalog_object_type": "ITEM_VARIATION",
+				"state":               state,
+				"location_id":         locationID,
+				"quantity":            count["quantity"],
+				"calculated_at":       now(),
+			}
+
+			s.inventoryCounts.put(inventoryCountID(catalogObjectID, locationID, state), calculated)
+
+			changes = append(changes, jsonObject{"type": "PHYSICAL_COUNT", "physical_count": count})
+			counts = append(counts, calculated)
+		}
+
+		return http.StatusOK, map[string]interface{}{"changes": changes, "counts": counts}
+	})
+}
+
+func (s *Server) handleInventoryPhysicalCount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+
+	id := pathID(r, "/v2/inventory/physical-counts/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := s.physicalCounts.get(id)
+	if count == nil {
+		writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("Physical count with ID `%s` not found.", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"count": count})
+}
+
+// handleBatchRetrieveInventoryCounts filters counts by catalog object, location, and state.  Results aren't
+// paginated.
+func (s *Server) handleBatchRetrieveInventoryCounts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	req := struct {
+		CatalogObjectIDs []string `json:"catalog_object_ids"`
+		LocationIDs      []string `json:"location_ids"`
+		States           []string `json:"states"`
+	}{}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matches := func(filter []string, v interface{}) bool {
+		if len(filter) == 0 {
+			return true
+		}
+
+		for _, f := range filter {
+			if f == v {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	counts := []jsonObject{}
+
+	for _, count := range s.inventoryCounts.all() {
+		if matches(req.CatalogObjectIDs, count["catalog_object_id"]) && matches(req.LocationIDs, count["location_id"]) && matches(req.States, count["state"]) {
+			counts = append(counts, count)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"counts": counts})
+}
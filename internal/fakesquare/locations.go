@@ -0,0 +1,130 @@
+package fakesquare
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	locationStatusActive   = "ACTIVE"
+	locationStatusInactive = "INACTIVE"
+)
+
+// addMainLocation creates the location every square account starts out with.
+func (s *Server) addMainLocation() {
+	id := s.newID()
+
+	s.locations.put(id, jsonObject{
+		"id":       id,
+		"name":     "Default Test Account",
+		"timezone": "UTC",
+		"status":   locationStatusActive,
+		"type":     "PHYSICAL",
+		"currency": "USD",
+		"country":  "US",
+	})
+}
+
+func (s *Server) registerLocations(mux *http.ServeMux) {
+	mux.HandleFunc("/v2/locations", s.handleLocations)
+	mux.HandleFunc("/v2/locations/", s.handleLocation)
+}
+
+func validateLocation(location jsonObject) error {
+	if status, ok := location["status"]; ok && status != locationStatusActive && status != locationStatusInactive {
+		return fmt.Errorf("invalid location status %v", status)
+	}
+
+	return nil
+}
+
+func (s *Server) handleLocations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"locations": s.locations.all(),
+		})
+	case http.MethodPost:
+		req := struct {
+			Location jsonObject `json:"location"`
+		}{}
+		if err := decodeBody(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+			return
+		}
+
+		if req.Location == nil || req.Location["name"] == nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, "location name is required")
+			return
+		}
+
+		if err := validateLocation(req.Location); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeInvalidValue, err.Error())
+			return
+		}
+
+		id := s.newID()
+		location := jsonObject{
+			"id":         id,
+			"status":     locationStatusActive,
+			"timezone":   "UTC",
+			"type":       "PHYSICAL",
+			"created_at": now(),
+		}
+		merge(location, req.Location)
+		location["id"] = id
+
+		s.locations.put(id, location)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"location": location,
+		})
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) handleLocation(w http.ResponseWriter, r *http.Request) {
+	id := pathID(r, "/v2/locations/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	location := s.locations.get(id)
+	if location == nil {
+		writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("Location with id %s not found", id))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"location": location,
+		})
+	case http.MethodPut:
+		req := struct {
+			Location jsonObject `json:"location"`
+		}{}
+		if err := decodeBody(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+			return
+		}
+
+		if err := validateLocation(req.Location); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeInvalidValue, err.Error())
+			return
+		}
+
+		delete(req.Location, "id")
+		merge(location, req.Location)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"location": location,
+		})
+	default:
+		methodNotAllowed(w)
+	}
+}
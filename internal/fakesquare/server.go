@@ -0,0 +1,216 @@
+// Package fakesquare implements an in-memory stand-in for the parts of the Square API used by the provider,
+// so that the provider can be exercised without a sandbox account.
+package fakesquare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is a fake Square API server.  Point the provider's base_url at URL to use it.
+type Server struct {
+	*httptest.Server
+
+	// URL is the base url of the fake API, equivalent to https://connect.squareup.com/v2.
+	URL   string
+	Token string
+
+	mu          sync.Mutex
+	catalog     *catalogStore
+	locations   *objectStore
+	customers   *objectStore
+	groups      *objectStore
+	idempotency map[string]*recordedResponse
+	nextID      int
+
+	customAttributeDefinitions *objectStore
+	customAttributes           *objectStore
+	physicalCounts             *objectStore
+	inventoryCounts            *objectStore
+	teamMembers                *objectStore
+	wageSettings               *objectStore
+}
+
+type recordedResponse struct {
+	status int
+	body   []byte
+}
+
+// NewServer starts a fake Square API server that accepts requests authorized with the given token.
+func NewServer(token string) *Server {
+	s := &Server{
+		Token:       token,
+		catalog:     newCatalogStore(),
+		locations:   newObjectStore(),
+		customers:   newObjectStore(),
+		groups:      newObjectStore(),
+		idempotency: map[string]*recordedResponse{},
+
+		customAttributeDefinitions: newObjectStore(),
+		customAttributes:           newObjectStore(),
+		physicalCounts:             newObjectStore(),
+		inventoryCounts:            newObjectStore(),
+		teamMembers:                newObjectStore(),
+		wageSettings:               newObjectStore(),
+	}
+
+	s.addMainLocation()
+
+	mux := http.NewServeMux()
+	s.registerCatalog(mux)
+	s.registerImages(mux)
+	s.registerLocations(mux)
+	s.registerCustomers(mux)
+	s.registerCustomerCustomAttributes(mux)
+	s.registerInventory(mux)
+	s.registerTeam(mux)
+
+	s.Server = httptest.NewServer(s.authorize(mux))
+	s.URL = s.Server.URL + "/v2"
+
+	return s
+}
+
+type squareError struct {
+	Category string `json:"category"`
+	Code     string `json:"code"`
+	Detail   string `json:"detail,omitempty"`
+	Field    string `json:"field,omitempty"`
+}
+
+const (
+	categoryInvalidRequest = "INVALID_REQUEST_ERROR"
+	categoryAuthentication = "AUTHENTICATION_ERROR"
+	categoryAPI            = "API_ERROR"
+
+	codeNotFound         = "NOT_FOUND"
+	codeBadRequest       = "BAD_REQUEST"
+	codeUnauthorized     = "UNAUTHORIZED"
+	codeVersionMismatch  = "VERSION_MISMATCH"
+	codeInvalidValue     = "INVALID_VALUE"
+	codeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+)
+
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.Token {
+			writeError(w, http.StatusUnauthorized, categoryAuthentication, codeUnauthorized, "invalid access token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, category, code, detail string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []*squareError{{
+			Category: category,
+			Code:     code,
+			Detail:   detail,
+		}},
+	})
+}
+
+func methodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, categoryInvalidRequest, codeMethodNotAllowed, "method not allowed")
+}
+
+// decodeBody decodes a json request body, keeping numbers exact so that catalog versions survive the round trip.
+func decodeBody(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("error decoding request body: %w", err)
+	}
+
+	return nil
+}
+
+// idempotent replays the recorded response for a reused idempotency key, or runs handle and records its
+// response.  It must be called with the server lock held.
+func (s *Server) idempotent(w http.ResponseWriter, key string, handle func() (int, interface{})) {
+	if key != "" {
+		if recorded, ok := s.idempotency[key]; ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(recorded.status)
+			_, _ = w.Write(recorded.body)
+
+			return
+		}
+	}
+
+	status, body := handle()
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, categoryAPI, "INTERNAL_SERVER_ERROR", err.Error())
+		return
+	}
+
+	if key != "" && status == http.StatusOK {
+		s.idempotency[key] = &recordedResponse{status: status, body: b}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+// newID generates a square style object id.  It must be called with the server lock held.
+func (s *Server) newID() string {
+	s.nextID++
+
+	return fmt.Sprintf("FAKE%016d", s.nextID)
+}
+
+func errorBody(category, code, detail string) map[string]interface{} {
+	return map[string]interface{}{
+		"errors": []*squareError{{
+			Category: category,
+			Code:     code,
+			Detail:   detail,
+		}},
+	}
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+func toInt64(v interface{}) int64 {
+	switch n := v.(type) {
+	case json.Number:
+		i, _ := n.Int64()
+		return i
+	case float64:
+		return int64(n)
+	case int64:
+		return n
+	case int:
+		return int64(n)
+	case string:
+		i, _ := strconv.ParseInt(n, 10, 64)
+		return i
+	}
+
+	return 0
+}
+
+func pathID(r *http.Request, prefix string) string {
+	return strings.TrimPrefix(r.URL.Path, prefix)
+}
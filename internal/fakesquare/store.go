@@ -0,0 +1,66 @@
+package fakesquare
+
+// jsonObject is an object stored by the fake exactly as it was sent as json.
+type jsonObject = map[string]interface{}
+
+// objectStore keeps json objects by id, remembering the order they were created in.
+type objectStore struct {
+	objects map[string]jsonObject
+	order   []string
+}
+
+func newObjectStore() *objectStore {
+	return &objectStore{
+		objects: map[string]jsonObject{},
+	}
+}
+
+func (o *objectStore) get(id string) jsonObject {
+	return o.objects[id]
+}
+
+func (o *objectStore) put(id string, object jsonObject) {
+	if _, ok := o.objects[id]; !ok {
+		o.order = append(o.order, id)
+	}
+
+	o.objects[id] = object
+}
+
+func (o *objectStore) remove(id string) bool {
+	if _, ok := o.objects[id]; !ok {
+		return false
+	}
+
+	delete(o.objects, id)
+
+	for i, oid := range o.order {
+		if oid == id {
+			o.order = append(o.order[:i], o.order[i+1:]...)
+			break
+		}
+	}
+
+	return true
+}
+
+func (o *objectStore) all() []jsonObject {
+	all := make([]jsonObject, len(o.order))
+	for i, id := range o.order {
+		all[i] = o.objects[id]
+	}
+
+	return all
+}
+
+// merge applies a sparse update to object.  Fields set to null are removed.
+func merge(object, update jsonObject) {
+	for k, v := range update {
+		if v == nil {
+			delete(object, k)
+			continue
+		}
+
+		object[k] = v
+	}
+}
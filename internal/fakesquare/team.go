@@ -0,0 +1,239 @@
+package fakesquare
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+func (s *Server) registerTeam(mux *http.ServeMux) {
+	mux.HandleFunc("/v2/team-members", s.handleTeamMembers)
+	mux.HandleFunc("/v2/team-members/", s.handleTeamMember)
+}
+
+// validateTeamMember checks a team member create or update.  It must be called with the server lock held.
+func (s *Server) validateTeamMember(teamMember jsonObject) error {
+	if status, ok := teamMember["status"]; ok && status != "ACTIVE" && status != "INACTIVE" {
+		return fmt.Errorf("invalid team member status %v", status)
+	}
+
+	assigned, ok := teamMember["assigned_locations"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	locationIDs, _ := assigned["location_ids"].([]interface{})
+
+	switch assigned["assignment_type"] {
+	case "ALL_CURRENT_AND_FUTURE_LOCATIONS":
+		if len(locationIDs) > 0 {
+			return fmt.Errorf("location_ids can't be set when assigned to all locations")
+		}
+	case "EXPLICIT_LOCATIONS":
+		for _, id := range locationIDs {
+			if s.locations.get(fmt.Sprint(id)) == nil {
+				return fmt.Errorf("location %v not found", id)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid assignment type %v", assigned["assignment_type"])
+	}
+
+	return nil
+}
+
+func validateWageSetting(wageSetting jsonObject) error {
+	jobs, _ := wageSetting["job_assignments"].([]interface{})
+
+	for _, j := range jobs {
+		job, _ := j.(map[string]interface{})
+
+		if title, _ := job["job_title"].(string); title == "" {
+			return fmt.Errorf("job_title is required")
+		}
+
+		switch job["pay_type"] {
+		case "HOURLY":
+			if job["hourly_rate"] == nil {
+				return fmt.Errorf("hourly_rate is required for HOURLY jobs")
+			}
+		case "SALARY":
+			if job["annual_rate"] == nil || job["weekly_hours"] == nil {
+				return fmt.Errorf("annual_rate and weekly_hours are required for SALARY jobs")
+			}
+		case "NONE":
+		default:
+			return fmt.Errorf("invalid pay type %v", job["pay_type"])
+		}
+	}
+
+	return nil
+}
+
+// addSalaryHourlyRates works out the hourly rate of each salaried job from its annual rate and weekly hours,
+// the way square does.
+func addSalaryHourlyRates(wageSetting jsonObject) {
+	jobs, _ := wageSetting["job_assignments"].([]interface{})
+
+	for _, j := range jobs {
+		job, _ := j.(map[string]interface{})
+		if job["pay_type"] != "SALARY" {
+			continue
+		}
+
+		annual, _ := job["annual_rate"].(map[string]interface{})
+
+		job["hourly_rate"] = map[string]interface{}{
+			"amount":   toInt64(annual["amount"]) / (toInt64(job["weekly_hours"]) * 52),
+			"currency": annual["currency"],
+		}
+	}
+}
+
+func (s *Server) handleTeamMembers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	req := struct {
+		IdempotencyKey string     `json:"idempotency_key"`
+		TeamMember     jsonObject `json:"team_member"`
+	}{}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.idempotent(w, req.IdempotencyKey, func() (int, interface{}) {
+		if err := s.validateTeamMember(req.TeamMember); err != nil {
+			return http.StatusBadRequest, errorBody(categoryInvalidRequest, codeInvalidValue, err.Error())
+		}
+
+		id := s.newID()
+		teamMember := jsonObject{
+			"status":             "ACTIVE",
+			"is_owner":           false,
+			"assigned_locations": map[string]interface{}{"assignment_type": "EXPLICIT_LOCATIONS"},
+			"created_at":         now(),
+			"updated_at":         now(),
+		}
+		merge(teamMember, req.TeamMember)
+		teamMember["id"] = id
+
+		s.teamMembers.put(id, teamMember)
+
+		return http.StatusOK, map[string]interface{}{"team_member": teamMember}
+	})
+}
+
+func (s *Server) handleTeamMember(w http.ResponseWriter, r *http.Request) {
+	// Wage settings are managed at /v2/team-members/{team_member_id}/wage-setting.
+	parts := strings.Split(pathID(r, "/v2/team-members/"), "/")
+	id := parts[0]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	teamMember := s.teamMembers.get(id)
+	if teamMember == nil {
+		writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("Team member with ID `%s` not found.", id))
+		return
+	}
+
+	switch {
+	case len(parts) == 2 && parts[1] == "wage-setting":
+		s.handleWageSetting(w, r, id)
+		return
+	case len(parts) != 1:
+		writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("%s not found", r.URL.Path))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"team_member": teamMember})
+	case http.MethodPut:
+		req := struct {
+			TeamMember jsonObject `json:"team_member"`
+		}{}
+		if err := decodeBody(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+			return
+		}
+
+		if err := s.validateTeamMember(req.TeamMember); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeInvalidValue, err.Error())
+			return
+		}
+
+		for _, field := range []string{"id", "is_owner", "created_at", "updated_at"} {
+			delete(req.TeamMember, field)
+		}
+
+		merge(teamMember, req.TeamMember)
+		teamMember["updated_at"] = now()
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"team_member": teamMember})
+	default:
+		// Square doesn't allow team members to be deleted, only deactivated.
+		methodNotAllowed(w)
+	}
+}
+
+// handleWageSetting must be called with the server lock held.
+func (s *Server) handleWageSetting(w http.ResponseWriter, r *http.Request, teamMemberID string) {
+	wageSetting := s.wageSettings.get(teamMemberID)
+
+	switch r.Method {
+	case http.MethodGet:
+		if wageSetting == nil {
+			writeError(w, http.StatusNotFound, categoryInvalidRequest, codeNotFound, fmt.Sprintf("Wage setting for team member `%s` not found.", teamMemberID))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"wage_setting": wageSetting})
+	case http.MethodPut:
+		req := struct {
+			WageSetting jsonObject `json:"wage_setting"`
+		}{}
+		if err := decodeBody(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeBadRequest, err.Error())
+			return
+		}
+
+		if err := validateWageSetting(req.WageSetting); err != nil {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeInvalidValue, err.Error())
+			return
+		}
+
+		if wageSetting == nil {
+			wageSetting = jsonObject{
+				"team_member_id": teamMemberID,
+				"created_at":     now(),
+				"version":        0,
+			}
+		}
+
+		if version, ok := req.WageSetting["version"]; ok && toInt64(version) != toInt64(wageSetting["version"]) {
+			writeError(w, http.StatusBadRequest, categoryInvalidRequest, codeVersionMismatch, fmt.Sprintf("wage setting of %s has version %d", teamMemberID, toInt64(wageSetting["version"])))
+			return
+		}
+
+		addSalaryHourlyRates(req.WageSetting)
+
+		wageSetting["job_assignments"] = req.WageSetting["job_assignments"]
+		wageSetting["is_overtime_exempt"] = req.WageSetting["is_overtime_exempt"]
+		wageSetting["version"] = toInt64(wageSetting["version"]) + 1
+		wageSetting["updated_at"] = now()
+
+		s.wageSettings.put(teamMemberID, wageSetting)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"wage_setting": wageSetting})
+	default:
+		methodNotAllowed(w)
+	}
+}
@@ -0,0 +1,249 @@
+package squareapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Houndie/square-go/objects"
+)
+
+const (
+	// CatalogMaxBatchObjects is the most objects square accepts in a single batch of a batch upsert,
+	// counting nested objects such as item variations.
+	CatalogMaxBatchObjects = 1000
+
+	// CatalogMaxTotalObjects is the most objects square accepts across all batches of a batch upsert,
+	// counting nested objects such as item variations.
+	CatalogMaxTotalObjects = 10000
+
+	// CatalogMaxDeleteObjects is the most object ids square accepts in a single batch delete.
+	CatalogMaxDeleteObjects = 200
+)
+
+type batchUpsertCatalogObjectsRequest struct {
+	IdempotencyKey string                    `json:"idempotency_key"`
+	Batches        []*catalogObjectBatchJSON `json:"batches"`
+}
+
+type catalogObjectBatchJSON struct {
+	Objects []json.RawMessage `json:"objects"`
+}
+
+// CountCatalogObjects returns how many objects o counts as towards square's batch limits, which is o itself
+// along with every object nested in it.
+func CountCatalogObjects(o *CatalogObject) (int, error) {
+	b, err := json.Marshal(o)
+	if err != nil {
+		return 0, fmt.Errorf("error marshalling catalog object: %w", err)
+	}
+
+	var m map[string]interface{}
+	if err := decodeJSON(b, &m); err != nil {
+		return 0, err
+	}
+
+	return countCatalogObjects(m), nil
+}
+
+func countCatalogObjects(m map[string]interface{}) int {
+	count := 0
+
+	walkCatalogObjects(m, func(object, data map[string]interface{}) {
+		count++
+	})
+
+	return count
+}
+
+// BatchUpsertCatalogObjects upserts catalogObjects in batches of at most CatalogMaxBatchObjects, returning
+// the upserted objects in the same order.  Temporary ids are scoped to each object, so every object may use
+// the same temporary ids, such as "#id", without colliding with the others.  An object is nil in the result
+// if square didn't return it.
+//
+// Square commits each batch on its own, so if some batches fail, the objects of the others are still
+// returned along with the error.  Only the objects that are nil were left unchanged.
+func (c *Client) BatchUpsertCatalogObjects(ctx context.Context, idempotencyKey string, catalogObjects []*CatalogObject) ([]*CatalogObject, error) {
+	req := &batchUpsertCatalogObjectsRequest{IdempotencyKey: idempotencyKey}
+	clientIDs := make([]string, len(catalogObjects))
+	batchCount, total := 0, 0
+
+	for i, o := range catalogObjects {
+		b, id, count, err := scopeTemporaryIDs(o, strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if count > CatalogMaxBatchObjects {
+			return nil, fmt.Errorf("catalog object %d holds %d objects, more than the %d allowed in a batch", i, count, CatalogMaxBatchObjects)
+		}
+
+		total += count
+		if total > CatalogMaxTotalObjects {
+			return nil, fmt.Errorf("cannot upsert more than %d catalog objects at once, including nested objects", CatalogMaxTotalObjects)
+		}
+
+		if len(req.Batches) == 0 || batchCount+count > CatalogMaxBatchObjects {
+			req.Batches = append(req.Batches, &catalogObjectBatchJSON{})
+			batchCount = 0
+		}
+
+		batch := req.Batches[len(req.Batches)-1]
+		batch.Objects = append(batch.Objects, b)
+		batchCount += count
+		clientIDs[i] = id
+	}
+
+	res := &struct {
+		Objects    []*CatalogObject            `json:"objects"`
+		IDMappings []*objects.CatalogIDMapping `json:"id_mappings"`
+	}{}
+
+	var batchErr error
+
+	if err := c.Do(ctx, http.MethodPost, "catalog/batch-upsert", nil, req, res); err != nil {
+		var errList *objects.ErrorList
+		if !errors.As(err, &errList) {
+			return nil, fmt.Errorf("error batch upserting catalog objects: %w", err)
+		}
+
+		batchErr = fmt.Errorf("error batch upserting catalog objects: %w", err)
+	}
+
+	mappings := make(map[string]string, len(res.IDMappings))
+	for _, m := range res.IDMappings {
+		mappings[m.ClientObjectID] = m.ObjectID
+	}
+
+	upserted := make(map[string]*CatalogObject, len(res.Objects))
+	for _, o := range res.Objects {
+		upserted[o.ID] = o
+	}
+
+	results := make([]*CatalogObject, len(catalogObjects))
+
+	for i, id := range clientIDs {
+		if mapped, ok := mappings[id]; ok {
+			id = mapped
+		}
+
+		results[i] = upserted[id]
+	}
+
+	return results, batchErr
+}
+
+// scopeTemporaryIDs marshals o, prefixing every temporary id it defines, along with every reference to
+// those ids, with scope.  It returns the json along with the object's (possibly rewritten) id and the number
+// of objects it counts as.
+func scopeTemporaryIDs(o *CatalogObject, scope string) (json.RawMessage, string, int, error) {
+	b, err := json.Marshal(o)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("error marshalling catalog object: %w", err)
+	}
+
+	var m map[string]interface{}
+	if err := decodeJSON(b, &m); err != nil {
+		return nil, "", 0, err
+	}
+
+	temporary := map[string]string{}
+
+	walkCatalogObjects(m, func(object, data map[string]interface{}) {
+		if id, _ := object["id"].(string); strings.HasPrefix(id, "#") {
+			temporary[id] = "#" + scope + "-" + strings.TrimPrefix(id, "#")
+		}
+	})
+
+	scoped, _ := replaceIDs(m, temporary).(map[string]interface{})
+
+	b, err = json.Marshal(scoped)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("error marshalling catalog object: %w", err)
+	}
+
+	id, _ := scoped["id"].(string)
+
+	return b, id, countCatalogObjects(scoped), nil
+}
+
+// replaceIDs returns v with every id and reference to an id that's a key of replacements replaced by its
+// value.  Other fields, such as names, are left alone even if they happen to match a temporary id.
+func replaceIDs(v interface{}, replacements map[string]string) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			if isIDField(k) {
+				t[k] = replaceStrings(e, replacements)
+				continue
+			}
+
+			t[k] = replaceIDs(e, replacements)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = replaceIDs(e, replacements)
+		}
+	}
+
+	return v
+}
+
+// isIDField reports whether a field holds an id, or a list of ids, such as item_id or tax_ids.
+func isIDField(k string) bool {
+	switch k {
+	case "id", "product_ids_any", "product_ids_all":
+		return true
+	}
+
+	return strings.HasSuffix(k, "_id") || strings.HasSuffix(k, "_ids")
+}
+
+// replaceStrings returns v, which is a string or a list of strings, with every string that's a key of
+// replacements replaced by its value.
+func replaceStrings(v interface{}, replacements map[string]string) interface{} {
+	switch t := v.(type) {
+	case string:
+		if r, ok := replacements[t]; ok {
+			return r
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = replaceStrings(e, replacements)
+		}
+	}
+
+	return v
+}
+
+// BatchDeleteCatalogObjects deletes the objects with the given ids, along with their children, returning
+// the ids of every deleted object.  Ids are sent in requests of at most CatalogMaxDeleteObjects.  Ids that
+// don't exist are ignored.
+func (c *Client) BatchDeleteCatalogObjects(ctx context.Context, ids []string) ([]string, error) {
+	deleted := []string{}
+
+	for start := 0; start < len(ids); start += CatalogMaxDeleteObjects {
+		end := start + CatalogMaxDeleteObjects
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		res := &struct {
+			DeletedObjectIDs []string `json:"deleted_object_ids"`
+		}{}
+
+		if err := c.Do(ctx, http.MethodPost, "catalog/batch-delete", nil, &struct {
+			ObjectIDs []string `json:"object_ids"`
+		}{ids[start:end]}, res); err != nil {
+			return nil, fmt.Errorf("error batch deleting catalog objects: %w", err)
+		}
+
+		deleted = append(deleted, res.DeletedObjectIDs...)
+	}
+
+	return deleted, nil
+}
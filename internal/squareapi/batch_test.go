@@ -0,0 +1,336 @@
+package squareapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+)
+
+func TestBatchUpsertCatalogObjects(t *testing.T) {
+	t.Parallel()
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/catalog/batch-upsert" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		req := struct {
+			IdempotencyKey string `json:"idempotency_key"`
+			Batches        []struct {
+				Objects []map[string]interface{} `json:"objects"`
+			} `json:"batches"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("error decoding request: %v", err)
+			return
+		}
+
+		if len(req.Batches) != 2 || len(req.Batches[0].Objects) != CatalogMaxBatchObjects || len(req.Batches[1].Objects) != 2 {
+			t.Errorf("expected batches of %d and 2 objects, found %d batches", CatalogMaxBatchObjects, len(req.Batches))
+			return
+		}
+
+		seen := map[string]struct{}{}
+		objs := []map[string]interface{}{}
+		mappings := []map[string]interface{}{}
+
+		// Objects are returned in reverse order, to check that results are matched up by id.
+		for i := len(req.Batches) - 1; i >= 0; i-- {
+			for j := len(req.Batches[i].Objects) - 1; j >= 0; j-- {
+				o := req.Batches[i].Objects[j]
+
+				id := o["id"].(string)
+				if _, ok := seen[id]; ok {
+					t.Errorf("temporary id %s sent more than once", id)
+				}
+
+				seen[id] = struct{}{}
+
+				if !strings.HasPrefix(id, "#") {
+					objs = append(objs, o)
+					continue
+				}
+
+				newID := "permanent" + id
+				mappings = append(mappings, map[string]interface{}{"client_object_id": id, "object_id": newID})
+
+				if data, ok := o["item_data"].(map[string]interface{}); ok {
+					variation := data["variations"].([]interface{})[0].(map[string]interface{})
+					if itemID := variation["item_variation_data"].(map[string]interface{})["item_id"]; itemID != id {
+						t.Errorf("expected variation to reference item %s, found %v", id, itemID)
+					}
+				}
+
+				o["id"] = newID
+				objs = append(objs, o)
+			}
+		}
+
+		b, _ := json.Marshal(map[string]interface{}{"objects": objs, "id_mappings": mappings})
+		_, _ = w.Write(b)
+	})
+
+	catalogObjects := []*CatalogObject{}
+
+	for i := 0; i < CatalogMaxBatchObjects; i++ {
+		catalogObjects = append(catalogObjects, NewCatalogObject(&objects.CatalogObject{
+			ID:   "#id",
+			Type: &objects.CatalogCategory{Name: fmt.Sprintf("category-%d", i)},
+		}))
+	}
+
+	catalogObjects = append(catalogObjects,
+		NewCatalogObject(&objects.CatalogObject{
+			ID: "#id",
+			Type: &objects.CatalogItem{
+				Name: "item",
+				Variations: []*objects.CatalogObject{{
+					ID:   "#variation",
+					Type: &objects.CatalogItemVariation{ItemID: "#id", Name: "variation"},
+				}},
+			},
+		}),
+		NewCatalogObject(&objects.CatalogObject{
+			ID:      "existing",
+			Version: 2,
+			Type:    &objects.CatalogCategory{Name: "#id"},
+		}),
+	)
+
+	res, err := c.BatchUpsertCatalogObjects(context.Background(), "key", catalogObjects)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != len(catalogObjects) {
+		t.Fatalf("expected %d results, found %d", len(catalogObjects), len(res))
+	}
+
+	for i := 0; i < CatalogMaxBatchObjects; i++ {
+		if name := res[i].Type.(*objects.CatalogCategory).Name; name != fmt.Sprintf("category-%d", i) {
+			t.Fatalf("expected result %d to be category-%d, found %s", i, i, name)
+		}
+	}
+
+	if _, ok := res[CatalogMaxBatchObjects].Type.(*objects.CatalogItem); !ok || !strings.HasPrefix(res[CatalogMaxBatchObjects].ID, "permanent") {
+		t.Fatalf("expected item with a permanent id, found %+v", res[CatalogMaxBatchObjects].CatalogObject)
+	}
+
+	existing := res[CatalogMaxBatchObjects+1]
+	if existing.ID != "existing" || existing.Type.(*objects.CatalogCategory).Name != "#id" {
+		t.Fatalf("expected existing object to be left alone, found %+v", existing.CatalogObject)
+	}
+}
+
+// testItemWithVariation returns an item carrying one variation, which counts as two objects towards the
+// batch limits.
+func testItemWithVariation(name string) *CatalogObject {
+	return NewCatalogObject(&objects.CatalogObject{
+		ID: "#id",
+		Type: &objects.CatalogItem{
+			Name: name,
+			Variations: []*objects.CatalogObject{{
+				ID:   "#variation",
+				Type: &objects.CatalogItemVariation{ItemID: "#id", Name: "variation"},
+			}},
+		},
+	})
+}
+
+func TestScopeTemporaryIDs(t *testing.T) {
+	t.Parallel()
+
+	o := NewCatalogObject(&objects.CatalogObject{
+		ID: "#id",
+		Type: &objects.CatalogItem{
+			Name:        "#id",
+			Description: "#variation",
+			Variations: []*objects.CatalogObject{{
+				ID:   "#variation",
+				Type: &objects.CatalogItemVariation{ItemID: "#id", Name: "#variation", SKU: "#id"},
+			}},
+		},
+	})
+	o.SetExtraField("#variation", "note", "#id")
+
+	b, id, count, err := scopeTemporaryIDs(o, "3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if id != "#3-id" || count != 2 {
+		t.Fatalf("expected scoped id #3-id counting 2 objects, found %s counting %d", id, count)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data := m["item_data"].(map[string]interface{})
+	if data["name"] != "#id" || data["description"] != "#variation" {
+		t.Fatalf("expected item name and description to be left alone, found %v", data)
+	}
+
+	variation := data["variations"].([]interface{})[0].(map[string]interface{})
+	if variation["id"] != "#3-variation" {
+		t.Fatalf("expected variation id to be scoped, found %v", variation["id"])
+	}
+
+	variationData := variation["item_variation_data"].(map[string]interface{})
+	if variationData["item_id"] != "#3-id" {
+		t.Fatalf("expected variation to reference the scoped item id, found %v", variationData["item_id"])
+	}
+
+	if variationData["name"] != "#variation" || variationData["sku"] != "#id" || variationData["note"] != "#id" {
+		t.Fatalf("expected variation name, sku and extra fields to be left alone, found %v", variationData)
+	}
+}
+
+func TestBatchUpsertCatalogObjectsNested(t *testing.T) {
+	t.Parallel()
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Batches []struct {
+				Objects []map[string]interface{} `json:"objects"`
+			} `json:"batches"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("error decoding request: %v", err)
+			return
+		}
+
+		// Each item counts as two objects, so a batch holds at most half as many items.
+		if len(req.Batches) != 3 || len(req.Batches[0].Objects) != CatalogMaxBatchObjects/2 || len(req.Batches[1].Objects) != CatalogMaxBatchObjects/2 || len(req.Batches[2].Objects) != 1 {
+			t.Errorf("expected batches of %d, %d and 1 items, found %d batches", CatalogMaxBatchObjects/2, CatalogMaxBatchObjects/2, len(req.Batches))
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	catalogObjects := []*CatalogObject{}
+	for i := 0; i < CatalogMaxBatchObjects+1; i++ {
+		catalogObjects = append(catalogObjects, testItemWithVariation(fmt.Sprintf("item-%d", i)))
+	}
+
+	if _, err := c.BatchUpsertCatalogObjects(context.Background(), "key", catalogObjects); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBatchUpsertCatalogObjectsPartialFailure(t *testing.T) {
+	t.Parallel()
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Batches []struct {
+				Objects []map[string]interface{} `json:"objects"`
+			} `json:"batches"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("error decoding request: %v", err)
+			return
+		}
+
+		// Only the first batch is committed.
+		objs := []map[string]interface{}{}
+		for _, o := range req.Batches[0].Objects {
+			if o["id"] == "#0-id" {
+				o["id"] = "permanent"
+			}
+
+			objs = append(objs, o)
+		}
+
+		b, _ := json.Marshal(map[string]interface{}{
+			"objects":     objs,
+			"id_mappings": []map[string]interface{}{{"client_object_id": "#0-id", "object_id": "permanent"}},
+			"errors":      []map[string]interface{}{{"category": "INVALID_REQUEST_ERROR", "code": "VERSION_MISMATCH"}},
+		})
+		_, _ = w.Write(b)
+	})
+
+	catalogObjects := []*CatalogObject{}
+	for i := 0; i < CatalogMaxBatchObjects+1; i++ {
+		catalogObjects = append(catalogObjects, NewCatalogObject(&objects.CatalogObject{
+			ID:   "#id",
+			Type: &objects.CatalogCategory{Name: fmt.Sprintf("category-%d", i)},
+		}))
+	}
+
+	res, err := c.BatchUpsertCatalogObjects(context.Background(), "key", catalogObjects)
+	if err == nil {
+		t.Fatalf("expected error for the failed batch")
+	}
+
+	if len(res) != len(catalogObjects) || res[0] == nil || res[0].ID != "permanent" {
+		t.Fatalf("expected the objects of the committed batch to be returned, found %v", res)
+	}
+
+	if res[CatalogMaxBatchObjects] != nil {
+		t.Fatalf("expected no result for the object of the failed batch, found %+v", res[CatalogMaxBatchObjects].CatalogObject)
+	}
+}
+
+func TestBatchUpsertCatalogObjectsTooMany(t *testing.T) {
+	t.Parallel()
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+
+	// Variations count towards the limit, so half as many items as the limit is already too many.
+	catalogObjects := make([]*CatalogObject, CatalogMaxTotalObjects/2+1)
+	for i := range catalogObjects {
+		catalogObjects[i] = testItemWithVariation(fmt.Sprintf("item-%d", i))
+	}
+
+	if _, err := c.BatchUpsertCatalogObjects(context.Background(), "key", catalogObjects); err == nil {
+		t.Fatalf("expected error upserting more than %d objects", CatalogMaxTotalObjects)
+	}
+}
+
+func TestBatchDeleteCatalogObjects(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		req := struct {
+			ObjectIDs []string `json:"object_ids"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("error decoding request: %v", err)
+			return
+		}
+
+		if len(req.ObjectIDs) > CatalogMaxDeleteObjects {
+			t.Errorf("expected at most %d ids per request, found %d", CatalogMaxDeleteObjects, len(req.ObjectIDs))
+		}
+
+		b, _ := json.Marshal(map[string]interface{}{"deleted_object_ids": req.ObjectIDs})
+		_, _ = w.Write(b)
+	})
+
+	ids := make([]string, CatalogMaxDeleteObjects+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("id-%d", i)
+	}
+
+	deleted, err := c.BatchDeleteCatalogObjects(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if requests != 2 || len(deleted) != len(ids) {
+		t.Fatalf("expected 2 requests deleting %d objects, found %d requests deleting %d", len(ids), requests, len(deleted))
+	}
+}
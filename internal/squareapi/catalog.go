@@ -0,0 +1,325 @@
+package squareapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/Houndie/square-go/objects"
+)
+
+// CatalogObject is a square-go catalog object, along with any data fields that square-go doesn't support.
+// Those are kept in Extra, keyed by the id of the object they belong to, which may be a nested object such
+// as an item variation, and then by field name.
+type CatalogObject struct {
+	*objects.CatalogObject
+	Extra map[string]map[string]interface{}
+}
+
+// NewCatalogObject wraps a square-go catalog object.
+func NewCatalogObject(o *objects.CatalogObject) *CatalogObject {
+	return &CatalogObject{
+		CatalogObject: o,
+		Extra:         map[string]map[string]interface{}{},
+	}
+}
+
+// ExtraField returns the value of a field square-go doesn't support, or nil if it isn't set.
+func (o *CatalogObject) ExtraField(id, field string) interface{} {
+	return o.Extra[id][field]
+}
+
+// ExtraBool returns the value of a boolean field square-go doesn't support, or def if it isn't set.
+func (o *CatalogObject) ExtraBool(id, field string, def bool) bool {
+	v, ok := o.ExtraField(id, field).(bool)
+	if !ok {
+		return def
+	}
+
+	return v
+}
+
+// SetExtraField sets a field square-go doesn't support on the object with the given id.
+func (o *CatalogObject) SetExtraField(id, field string, v interface{}) {
+	if o.Extra == nil {
+		o.Extra = map[string]map[string]interface{}{}
+	}
+
+	if o.Extra[id] == nil {
+		o.Extra[id] = map[string]interface{}{}
+	}
+
+	o.Extra[id][field] = v
+}
+
+// MarshalJSON marshals the square-go object, then adds the extra fields to the data of the objects they
+// belong to.  present_at_all_locations is always sent, as square-go omits it when it's false, which square
+// treats as true.
+func (o *CatalogObject) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(o.CatalogObject)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling catalog object: %w", err)
+	}
+
+	var m map[string]interface{}
+	if err := decodeJSON(b, &m); err != nil {
+		return nil, err
+	}
+
+	walkCatalogObjects(m, func(object, data map[string]interface{}) {
+		if _, ok := object["present_at_all_locations"]; !ok {
+			object["present_at_all_locations"] = false
+		}
+
+		id, _ := object["id"].(string)
+		for k, v := range o.Extra[id] {
+			data[k] = v
+		}
+	})
+
+	b, err = json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling catalog object: %w", err)
+	}
+
+	return b, nil
+}
+
+// UnmarshalJSON decodes the object with square-go, keeping every data field in Extra so that fields
+// square-go drops are still available.
+func (o *CatalogObject) UnmarshalJSON(b []byte) error {
+	var m map[string]interface{}
+	if err := decodeJSON(b, &m); err != nil {
+		return err
+	}
+
+	o.Extra = map[string]map[string]interface{}{}
+
+	walkCatalogObjects(m, func(object, data map[string]interface{}) {
+		id, _ := object["id"].(string)
+		extra := map[string]interface{}{}
+
+		for k, v := range data {
+			extra[k] = v
+		}
+
+		o.Extra[id] = extra
+
+		objectType, _ := object["type"].(string)
+		for _, field := range undecodableFields[objects.CatalogObjectEnumType(objectType)] {
+			delete(data, field)
+		}
+	})
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("error marshalling catalog object: %w", err)
+	}
+
+	o.CatalogObject = &objects.CatalogObject{}
+	if err := json.Unmarshal(b, o.CatalogObject); err != nil {
+		return fmt.Errorf("error unmarshalling catalog object: %w", err)
+	}
+
+	return nil
+}
+
+// undecodableFields are fields that square-go fails to decode, which are removed before decoding.  They're
+// still available in Extra.  square-go expects pricing rule dates to be timestamps, while square sends them
+// as YYYY-MM-DD.
+var undecodableFields = map[objects.CatalogObjectEnumType][]string{
+	objects.CatalogObjectEnumTypePricingRule: {"valid_from_date", "valid_until_date"},
+}
+
+func decodeJSON(b []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("error decoding json: %w", err)
+	}
+
+	return nil
+}
+
+// walkCatalogObjects calls f for every catalog object in v, including nested ones, along with the field
+// holding that object's data.
+func walkCatalogObjects(v interface{}, f func(object, data map[string]interface{})) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if data := catalogObjectData(t); data != nil {
+			f(t, data)
+		}
+
+		for _, e := range t {
+			walkCatalogObjects(e, f)
+		}
+	case []interface{}:
+		for _, e := range t {
+			walkCatalogObjects(e, f)
+		}
+	}
+}
+
+// catalogObjectData returns the data field of m if m is a json catalog object, which has an id, a type, and a
+// field holding the data for that type.
+func catalogObjectData(m map[string]interface{}) map[string]interface{} {
+	objectType, ok := m["type"].(string)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := m["id"]; !ok {
+		return nil
+	}
+
+	key := strings.ToLower(objectType) + "_data"
+	if objectType == string(objects.CatalogObjectEnumTypeItemOptionVal) {
+		key = "item_option_value_data"
+	}
+
+	data, _ := m[key].(map[string]interface{})
+
+	return data
+}
+
+type upsertCatalogObjectRequest struct {
+	IdempotencyKey string         `json:"idempotency_key"`
+	Object         *CatalogObject `json:"object"`
+}
+
+type upsertCatalogObjectResponse struct {
+	CatalogObject *CatalogObject              `json:"catalog_object"`
+	IDMappings    []*objects.CatalogIDMapping `json:"id_mappings"`
+}
+
+func (c *Client) UpsertCatalogObject(ctx context.Context, idempotencyKey string, object *CatalogObject) (*CatalogObject, error) {
+	res := &upsertCatalogObjectResponse{}
+
+	if err := c.Do(ctx, http.MethodPost, "catalog/object", nil, &upsertCatalogObjectRequest{
+		IdempotencyKey: idempotencyKey,
+		Object:         object,
+	}, res); err != nil {
+		return nil, fmt.Errorf("error upserting catalog object: %w", err)
+	}
+
+	return res.CatalogObject, nil
+}
+
+func (c *Client) RetrieveCatalogObject(ctx context.Context, id string) (*CatalogObject, error) {
+	res := &struct {
+		Object *CatalogObject `json:"object"`
+	}{}
+
+	if err := c.Do(ctx, http.MethodGet, path.Join("catalog/object", id), nil, nil, res); err != nil {
+		return nil, fmt.Errorf("error retrieving catalog object: %w", err)
+	}
+
+	return res.Object, nil
+}
+
+func (c *Client) DeleteCatalogObject(ctx context.Context, id string) ([]string, error) {
+	res := &struct {
+		DeletedObjectIDs []string `json:"deleted_object_ids"`
+	}{}
+
+	if err := c.Do(ctx, http.MethodDelete, path.Join("catalog/object", id), nil, nil, res); err != nil {
+		return nil, fmt.Errorf("error deleting catalog object: %w", err)
+	}
+
+	return res.DeletedObjectIDs, nil
+}
+
+// ListCatalog lists every catalog object of the given types, following pagination.  With no types, every
+// top level object is listed.
+func (c *Client) ListCatalog(ctx context.Context, types []objects.CatalogObjectEnumType) ([]*CatalogObject, error) {
+	typeNames := make([]string, len(types))
+	for i, t := range types {
+		typeNames[i] = string(t)
+	}
+
+	all := []*CatalogObject{}
+	cursor := ""
+
+	for {
+		query := url.Values{}
+		if len(typeNames) > 0 {
+			query.Set("types", strings.Join(typeNames, ","))
+		}
+
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		res := &struct {
+			Objects []*CatalogObject `json:"objects"`
+			Cursor  string           `json:"cursor"`
+		}{}
+
+		if err := c.Do(ctx, http.MethodGet, "catalog/list", query, nil, res); err != nil {
+			return nil, fmt.Errorf("error listing catalog objects: %w", err)
+		}
+
+		all = append(all, res.Objects...)
+
+		if res.Cursor == "" {
+			return all, nil
+		}
+
+		cursor = res.Cursor
+	}
+}
+
+// SearchCatalogObjectsExact searches for catalog objects of the given types whose attribute equals value,
+// following pagination.  Square matches the value case insensitively.  With no types, objects of every type
+// are searched.
+func (c *Client) SearchCatalogObjectsExact(ctx context.Context, types []objects.CatalogObjectEnumType, attribute, value string) ([]*CatalogObject, error) {
+	typeNames := make([]string, len(types))
+	for i, t := range types {
+		typeNames[i] = string(t)
+	}
+
+	all := []*CatalogObject{}
+	cursor := ""
+
+	for {
+		req := map[string]interface{}{
+			"query": map[string]interface{}{
+				"exact_query": map[string]interface{}{
+					"attribute_name":  attribute,
+					"attribute_value": value,
+				},
+			},
+		}
+
+		if len(typeNames) > 0 {
+			req["object_types"] = typeNames
+		}
+
+		if cursor != "" {
+			req["cursor"] = cursor
+		}
+
+		res := &struct {
+			Objects []*CatalogObject `json:"objects"`
+			Cursor  string           `json:"cursor"`
+		}{}
+
+		if err := c.Do(ctx, http.MethodPost, "catalog/search", nil, req, res); err != nil {
+			return nil, fmt.Errorf("error searching catalog objects: %w", err)
+		}
+
+		all = append(all, res.Objects...)
+
+		if res.Cursor == "" {
+			return all, nil
+		}
+
+		cursor = res.Cursor
+	}
+}
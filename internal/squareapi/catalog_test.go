@@ -0,0 +1,132 @@
+package squareapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+)
+
+func TestCatalogObjectMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	object := NewCatalogObject(&objects.CatalogObject{
+		ID:                   "#item",
+		PresentAtLocationIDs: []string{"location"},
+		Type: &objects.CatalogItem{
+			Name: "item",
+			Variations: []*objects.CatalogObject{
+				{
+					ID:                    "#variation",
+					PresentAtAllLocations: true,
+					Type:                  &objects.CatalogItemVariation{Name: "variation"},
+				},
+			},
+		},
+	})
+	object.SetExtraField("#variation", "sellable", false)
+
+	b, err := json.Marshal(object)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v, ok := m["present_at_all_locations"]; !ok || v != false {
+		t.Fatalf("expected item to be sent with present_at_all_locations false, found %v", m)
+	}
+
+	variation := m["item_data"].(map[string]interface{})["variations"].([]interface{})[0].(map[string]interface{})
+	if variation["present_at_all_locations"] != true {
+		t.Fatalf("expected variation to keep present_at_all_locations, found %v", variation)
+	}
+
+	variationData := variation["item_variation_data"].(map[string]interface{})
+	if v, ok := variationData["sellable"]; !ok || v != false {
+		t.Fatalf("expected variation to be sent with sellable false, found %v", variationData)
+	}
+
+	if variationData["name"] != "variation" {
+		t.Fatalf("expected variation to keep its name, found %v", variationData)
+	}
+}
+
+func TestCatalogObjectUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	body := `{
+		"id": "item",
+		"type": "ITEM",
+		"version": 3,
+		"item_data": {
+			"name": "item",
+			"variations": [{
+				"id": "variation",
+				"type": "ITEM_VARIATION",
+				"item_variation_data": {"name": "variation", "sellable": true, "stockable": false}
+			}]
+		}
+	}`
+
+	object := &CatalogObject{}
+	if err := json.Unmarshal([]byte(body), object); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if object.ID != "item" || object.Version != 3 {
+		t.Fatalf("expected square-go object to be decoded, found %+v", object.CatalogObject)
+	}
+
+	item, ok := object.Type.(*objects.CatalogItem)
+	if !ok || len(item.Variations) != 1 || item.Variations[0].ID != "variation" {
+		t.Fatalf("expected item with one variation, found %+v", object.Type)
+	}
+
+	if v := object.ExtraField("variation", "sellable"); v != true {
+		t.Fatalf("expected sellable to be kept, found %v", v)
+	}
+
+	if v := object.ExtraField("variation", "stockable"); v != false {
+		t.Fatalf("expected stockable to be kept, found %v", v)
+	}
+
+	if v := object.ExtraField("item", "name"); v != "item" {
+		t.Fatalf("expected item data to be kept, found %v", v)
+	}
+
+	if v := object.ExtraField("missing", "name"); v != nil {
+		t.Fatalf("expected nil for a missing object, found %v", v)
+	}
+}
+
+func TestCatalogObjectUnmarshalJSONPricingRuleDates(t *testing.T) {
+	t.Parallel()
+
+	body := `{
+		"id": "rule",
+		"type": "PRICING_RULE",
+		"pricing_rule_data": {"name": "rule", "valid_from_date": "2021-06-01", "valid_until_date": "2021-06-30"}
+	}`
+
+	object := &CatalogObject{}
+	if err := json.Unmarshal([]byte(body), object); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rule, ok := object.Type.(*objects.CatalogPricingRule)
+	if !ok || rule.Name != "rule" {
+		t.Fatalf("expected pricing rule to be decoded, found %+v", object.Type)
+	}
+
+	if v := object.ExtraField("rule", "valid_from_date"); v != "2021-06-01" {
+		t.Fatalf("expected valid_from_date to be kept, found %v", v)
+	}
+
+	if v := object.ExtraField("rule", "valid_until_date"); v != "2021-06-30" {
+		t.Fatalf("expected valid_until_date to be kept, found %v", v)
+	}
+}
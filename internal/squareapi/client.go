@@ -0,0 +1,204 @@
+// Package squareapi is a small client for the parts of the square API that aren't covered by square-go.
+// Errors returned by square are reported with the same types square-go uses, so callers can handle both
+// in the same way.
+package squareapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/Houndie/square-go/objects"
+)
+
+const (
+	productionEndpoint = "https://connect.squareup.com/v2"
+	sandboxEndpoint    = "https://connect.squareupsandbox.com/v2"
+)
+
+// ErrMaxBackoff is returned when requests are still being rate limited after the configured retry time.
+var ErrMaxBackoff = errors.New("rate limited, hit max backoff amount")
+
+type Client struct {
+	httpClient   *http.Client
+	token        string
+	endpoint     *url.URL
+	maxRetryTime time.Duration
+}
+
+// NewClient creates a client that authenticates with token.  A negative maxRetryTime disables retrying
+// requests that were rate limited.
+func NewClient(token string, environment objects.Environment, httpClient *http.Client, maxRetryTime time.Duration) (*Client, error) {
+	var endpoint string
+
+	switch environment {
+	case objects.Production:
+		endpoint = productionEndpoint
+	case objects.Sandbox:
+		endpoint = sandboxEndpoint
+	default:
+		return nil, errors.New("unknown environment")
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing endpoint url: %w", err)
+	}
+
+	return &Client{
+		httpClient:   httpClient,
+		token:        token,
+		endpoint:     u,
+		maxRetryTime: maxRetryTime,
+	}, nil
+}
+
+type withErrors struct {
+	Errors []*objects.Error `json:"errors"`
+}
+
+// Do sends req as json to the given path, relative to the square api root, and decodes the response into
+// res.  Either of req and res may be nil.  If square reports errors, they're returned as an
+// *objects.ErrorList, and res holds whatever else the response contained.
+func (c *Client) Do(ctx context.Context, method, p string, query url.Values, req, res interface{}) error {
+	var body []byte
+
+	if req != nil {
+		var err error
+
+		body, err = json.Marshal(req)
+		if err != nil {
+			return fmt.Errorf("error marshalling request: %w", err)
+		}
+	}
+
+	return c.send(ctx, method, p, query, body, "application/json", res)
+}
+
+// send sends body to the given path, retrying it while it's rate limited.
+func (c *Client) send(ctx context.Context, method, p string, query url.Values, body []byte, contentType string, res interface{}) error {
+	u := *c.endpoint
+	u.Path = path.Join(u.Path, p)
+	u.RawQuery = query.Encode()
+
+	done := time.Now().Add(c.maxRetryTime)
+	wait := time.Second
+
+	for {
+		err := c.do(ctx, method, u.String(), body, contentType, res)
+		if !isRateLimited(err) || c.maxRetryTime < 0 {
+			return err
+		}
+
+		if time.Now().Add(wait).After(done) {
+			return ErrMaxBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("error waiting to retry request: %w", ctx.Err())
+		case <-time.After(wait):
+		}
+
+		wait = time.Duration(math.Min(float64(wait*2), float64(time.Minute))) //nolint:gomnd
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, u string, body []byte, contentType string, res interface{}) error {
+	var reqBody io.Reader = http.NoBody
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("error creating http request: %w", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	httpReq.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("error with http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return parseResponse(resp, res)
+}
+
+func parseResponse(resp *http.Response, res interface{}) error {
+	var codeErr error
+	if resp.StatusCode != http.StatusOK {
+		codeErr = objects.UnexpectedCodeError(resp.StatusCode)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if codeErr != nil {
+			return codeErr
+		}
+
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+
+	errs := withErrors{}
+	if err := json.Unmarshal(b, &errs); err != nil {
+		if codeErr != nil {
+			return codeErr
+		}
+
+		return fmt.Errorf("error unmarshalling json response: %w", err)
+	}
+
+	if len(errs.Errors) != 0 {
+		// Batch endpoints report the errors of failed batches alongside the results of the others, so
+		// whatever results there are are decoded as well.
+		if res != nil {
+			_ = json.Unmarshal(b, res)
+		}
+
+		return &objects.ErrorList{Errors: errs.Errors}
+	}
+
+	if codeErr != nil {
+		return codeErr
+	}
+
+	if res == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(b, res); err != nil {
+		return fmt.Errorf("error unmarshalling json response: %w", err)
+	}
+
+	return nil
+}
+
+func isRateLimited(err error) bool {
+	var errList *objects.ErrorList
+	if !errors.As(err, &errList) {
+		return false
+	}
+
+	for _, e := range errList.Errors {
+		if e.Code != objects.ErrorCodeRateLimited {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,101 @@
+package squareapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+)
+
+func testClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL + "/v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Client{
+		httpClient:   server.Client(),
+		token:        "token",
+		endpoint:     u,
+		maxRetryTime: -1,
+	}
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("missing authorization header")
+		}
+
+		if r.URL.Path != "/v2/locations/id" || r.URL.Query().Get("q") != "value" {
+			t.Errorf("unexpected url %s", r.URL)
+		}
+
+		_, _ = w.Write([]byte(`{"location": {"id": "id", "name": "name"}}`))
+	})
+
+	res := &locationResponse{}
+	if err := c.Do(context.Background(), http.MethodGet, "locations/id", url.Values{"q": {"value"}}, nil, res); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if res.Location.Name != "name" {
+		t.Fatalf("unexpected response %v", res.Location)
+	}
+}
+
+func TestDoErrors(t *testing.T) {
+	t.Parallel()
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/errors":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors": [{"category": "INVALID_REQUEST_ERROR", "code": "NOT_FOUND"}]}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	})
+
+	err := c.Do(context.Background(), http.MethodGet, "errors", nil, nil, nil)
+
+	var errList *objects.ErrorList
+	if !errors.As(err, &errList) || errList.Errors[0].Code != objects.ErrorCodeNotFound {
+		t.Fatalf("expected not found error list, found %v", err)
+	}
+
+	err = c.Do(context.Background(), http.MethodGet, "other", nil, nil, nil)
+
+	var codeErr objects.UnexpectedCodeError
+	if !errors.As(err, &codeErr) || int(codeErr) != http.StatusBadGateway {
+		t.Fatalf("expected unexpected code error, found %v", err)
+	}
+}
+
+func TestWithClearedFields(t *testing.T) {
+	t.Parallel()
+
+	m, err := withClearedFields(&Location{Name: "name"}, []string{"description"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v, ok := m["description"]; !ok || v != nil {
+		t.Fatalf("expected description to be null, found %v", m)
+	}
+
+	if m["name"] != "name" {
+		t.Fatalf("expected name to be kept, found %v", m)
+	}
+}
@@ -0,0 +1,122 @@
+package squareapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+)
+
+// CustomAttributeDefinition mirrors the custom attribute definition object of square's customer custom
+// attributes API.  Schema is kept as the raw json schema square expects.
+type CustomAttributeDefinition struct {
+	Key         string          `json:"key,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+	Visibility  string          `json:"visibility,omitempty"`
+	Version     int64           `json:"version,omitempty"`
+}
+
+// CustomAttribute is the value of a custom attribute on a single customer.  Value is kept as raw json, as its
+// shape depends on the definition's schema.
+type CustomAttribute struct {
+	Key     string          `json:"key,omitempty"`
+	Value   json.RawMessage `json:"value,omitempty"`
+	Version int64           `json:"version,omitempty"`
+}
+
+const customerCustomAttributeDefinitionsPath = "customers/custom-attribute-definitions"
+
+type customAttributeDefinitionResponse struct {
+	CustomAttributeDefinition *CustomAttributeDefinition `json:"custom_attribute_definition"`
+}
+
+type customAttributeResponse struct {
+	CustomAttribute *CustomAttribute `json:"custom_attribute"`
+}
+
+func (c *Client) CreateCustomerCustomAttributeDefinition(ctx context.Context, idempotencyKey string, definition *CustomAttributeDefinition) (*CustomAttributeDefinition, error) {
+	res := &customAttributeDefinitionResponse{}
+
+	if err := c.Do(ctx, http.MethodPost, customerCustomAttributeDefinitionsPath, nil, map[string]interface{}{
+		"idempotency_key":             idempotencyKey,
+		"custom_attribute_definition": definition,
+	}, res); err != nil {
+		return nil, fmt.Errorf("error creating customer custom attribute definition: %w", err)
+	}
+
+	return res.CustomAttributeDefinition, nil
+}
+
+func (c *Client) RetrieveCustomerCustomAttributeDefinition(ctx context.Context, key string) (*CustomAttributeDefinition, error) {
+	res := &customAttributeDefinitionResponse{}
+
+	if err := c.Do(ctx, http.MethodGet, path.Join(customerCustomAttributeDefinitionsPath, key), nil, nil, res); err != nil {
+		return nil, fmt.Errorf("error retrieving customer custom attribute definition: %w", err)
+	}
+
+	return res.CustomAttributeDefinition, nil
+}
+
+// UpdateCustomerCustomAttributeDefinition makes a sparse update of the definition with the given key.  The
+// description is removed if it's named in clear.
+func (c *Client) UpdateCustomerCustomAttributeDefinition(ctx context.Context, key string, definition *CustomAttributeDefinition, clear []string) (*CustomAttributeDefinition, error) {
+	body, err := withClearedFields(definition, clear)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &customAttributeDefinitionResponse{}
+
+	if err := c.Do(ctx, http.MethodPut, path.Join(customerCustomAttributeDefinitionsPath, key), nil, map[string]interface{}{
+		"custom_attribute_definition": body,
+	}, res); err != nil {
+		return nil, fmt.Errorf("error updating customer custom attribute definition: %w", err)
+	}
+
+	return res.CustomAttributeDefinition, nil
+}
+
+// DeleteCustomerCustomAttributeDefinition deletes a definition, along with its value on every customer.
+func (c *Client) DeleteCustomerCustomAttributeDefinition(ctx context.Context, key string) error {
+	if err := c.Do(ctx, http.MethodDelete, path.Join(customerCustomAttributeDefinitionsPath, key), nil, nil, nil); err != nil {
+		return fmt.Errorf("error deleting customer custom attribute definition: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertCustomerCustomAttribute sets the value of a custom attribute on a customer, creating it if the
+// customer doesn't have one yet.
+func (c *Client) UpsertCustomerCustomAttribute(ctx context.Context, idempotencyKey, customerID string, attribute *CustomAttribute) (*CustomAttribute, error) {
+	res := &customAttributeResponse{}
+
+	if err := c.Do(ctx, http.MethodPost, path.Join("customers", customerID, "custom-attributes", attribute.Key), nil, map[string]interface{}{
+		"idempotency_key":  idempotencyKey,
+		"custom_attribute": attribute,
+	}, res); err != nil {
+		return nil, fmt.Errorf("error upserting customer custom attribute: %w", err)
+	}
+
+	return res.CustomAttribute, nil
+}
+
+func (c *Client) RetrieveCustomerCustomAttribute(ctx context.Context, customerID, key string) (*CustomAttribute, error) {
+	res := &customAttributeResponse{}
+
+	if err := c.Do(ctx, http.MethodGet, path.Join("customers", customerID, "custom-attributes", key), nil, nil, res); err != nil {
+		return nil, fmt.Errorf("error retrieving customer custom attribute: %w", err)
+	}
+
+	return res.CustomAttribute, nil
+}
+
+func (c *Client) DeleteCustomerCustomAttribute(ctx context.Context, customerID, key string) error {
+	if err := c.Do(ctx, http.MethodDelete, path.Join("customers", customerID, "custom-attributes", key), nil, nil, nil); err != nil {
+		return fmt.Errorf("error deleting customer custom attribute: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package squareapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/Houndie/square-go/objects"
+)
+
+// Customer mirrors the customer object of square's customers API, which square-go doesn't cover.  Birthdays
+// are kept as the YYYY-MM-DD strings square uses, with a year of 0000 when the year isn't known.
+type Customer struct {
+	ID           string           `json:"id,omitempty"`
+	GivenName    string           `json:"given_name,omitempty"`
+	FamilyName   string           `json:"family_name,omitempty"`
+	CompanyName  string           `json:"company_name,omitempty"`
+	EmailAddress string           `json:"email_address,omitempty"`
+	PhoneNumber  string           `json:"phone_number,omitempty"`
+	Address      *objects.Address `json:"address,omitempty"`
+	Birthday     string           `json:"birthday,omitempty"`
+	Note         string           `json:"note,omitempty"`
+	ReferenceID  string           `json:"reference_id,omitempty"`
+	Version      int64            `json:"version,omitempty"`
+
+	// GroupIDs is read only, groups are changed with AddGroupToCustomer and RemoveGroupFromCustomer.
+	GroupIDs []string `json:"group_ids,omitempty"`
+}
+
+// CustomerFilter picks the customers returned by SearchCustomers.  Each field that's set must match
+// exactly.
+type CustomerFilter struct {
+	EmailAddress string
+	PhoneNumber  string
+	ReferenceID  string
+}
+
+type customerResponse struct {
+	Customer *Customer `json:"customer"`
+}
+
+func (c *Client) CreateCustomer(ctx context.Context, idempotencyKey string, customer *Customer) (*Customer, error) {
+	req := &struct {
+		*Customer
+		IdempotencyKey string `json:"idempotency_key"`
+	}{customer, idempotencyKey}
+
+	res := &customerResponse{}
+
+	if err := c.Do(ctx, http.MethodPost, "customers", nil, req, res); err != nil {
+		return nil, fmt.Errorf("error creating customer: %w", err)
+	}
+
+	return res.Customer, nil
+}
+
+func (c *Client) RetrieveCustomer(ctx context.Context, id string) (*Customer, error) {
+	res := &customerResponse{}
+
+	if err := c.Do(ctx, http.MethodGet, path.Join("customers", id), nil, nil, res); err != nil {
+		return nil, fmt.Errorf("error retrieving customer: %w", err)
+	}
+
+	return res.Customer, nil
+}
+
+// UpdateCustomer makes a sparse update of a customer.  Fields left empty in customer are left unchanged,
+// unless they're named in clear, in which case they're removed.
+func (c *Client) UpdateCustomer(ctx context.Context, id string, customer *Customer, clear []string) (*Customer, error) {
+	body, err := withClearedFields(customer, clear)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &customerResponse{}
+
+	if err := c.Do(ctx, http.MethodPut, path.Join("customers", id), nil, body, res); err != nil {
+		return nil, fmt.Errorf("error updating customer: %w", err)
+	}
+
+	return res.Customer, nil
+}
+
+func (c *Client) DeleteCustomer(ctx context.Context, id string) error {
+	if err := c.Do(ctx, http.MethodDelete, path.Join("customers", id), nil, nil, nil); err != nil {
+		return fmt.Errorf("error deleting customer: %w", err)
+	}
+
+	return nil
+}
+
+// SearchCustomers returns every customer matching filter, following pagination.
+func (c *Client) SearchCustomers(ctx context.Context, filter *CustomerFilter) ([]*Customer, error) {
+	exact := map[string]interface{}{}
+
+	for field, v := range map[string]string{
+		"email_address": filter.EmailAddress,
+		"phone_number":  filter.PhoneNumber,
+		"reference_id":  filter.ReferenceID,
+	} {
+		if v != "" {
+			exact[field] = map[string]interface{}{"exact": v}
+		}
+	}
+
+	all := []*Customer{}
+	cursor := ""
+
+	for {
+		req := map[string]interface{}{
+			"query": map[string]interface{}{"filter": exact},
+		}
+
+		if cursor != "" {
+			req["cursor"] = cursor
+		}
+
+		res := &struct {
+			Customers []*Customer `json:"customers"`
+			Cursor    string      `json:"cursor"`
+		}{}
+
+		if err := c.Do(ctx, http.MethodPost, "customers/search", nil, req, res); err != nil {
+			return nil, fmt.Errorf("error searching customers: %w", err)
+		}
+
+		all = append(all, res.Customers...)
+
+		if res.Cursor == "" {
+			return all, nil
+		}
+
+		cursor = res.Cursor
+	}
+}
+
+// CustomerGroup mirrors the customer group object of square's customers API.
+type CustomerGroup struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type customerGroupResponse struct {
+	Group *CustomerGroup `json:"group"`
+}
+
+func (c *Client) CreateCustomerGroup(ctx context.Context, idempotencyKey string, group *CustomerGroup) (*CustomerGroup, error) {
+	res := &customerGroupResponse{}
+
+	if err := c.Do(ctx, http.MethodPost, "customers/groups", nil, map[string]interface{}{
+		"idempotency_key": idempotencyKey,
+		"group":           group,
+	}, res); err != nil {
+		return nil, fmt.Errorf("error creating customer group: %w", err)
+	}
+
+	return res.Group, nil
+}
+
+func (c *Client) RetrieveCustomerGroup(ctx context.Context, id string) (*CustomerGroup, error) {
+	res := &customerGroupResponse{}
+
+	if err := c.Do(ctx, http.MethodGet, path.Join("customers/groups", id), nil, nil, res); err != nil {
+		return nil, fmt.Errorf("error retrieving customer group: %w", err)
+	}
+
+	return res.Group, nil
+}
+
+func (c *Client) UpdateCustomerGroup(ctx context.Context, id string, group *CustomerGroup) (*CustomerGroup, error) {
+	res := &customerGroupResponse{}
+
+	if err := c.Do(ctx, http.MethodPut, path.Join("customers/groups", id), nil, map[string]interface{}{"group": group}, res); err != nil {
+		return nil, fmt.Errorf("error updating customer group: %w", err)
+	}
+
+	return res.Group, nil
+}
+
+func (c *Client) DeleteCustomerGroup(ctx context.Context, id string) error {
+	if err := c.Do(ctx, http.MethodDelete, path.Join("customers/groups", id), nil, nil, nil); err != nil {
+		return fmt.Errorf("error deleting customer group: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) AddGroupToCustomer(ctx context.Context, customerID, groupID string) error {
+	if err := c.Do(ctx, http.MethodPut, path.Join("customers", customerID, "groups", groupID), nil, nil, nil); err != nil {
+		return fmt.Errorf("error adding customer to group: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) RemoveGroupFromCustomer(ctx context.Context, customerID, groupID string) error {
+	if err := c.Do(ctx, http.MethodDelete, path.Join("customers", customerID, "groups", groupID), nil, nil, nil); err != nil {
+		return fmt.Errorf("error removing customer from group: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package squareapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+)
+
+type createCatalogImageRequest struct {
+	IdempotencyKey string         `json:"idempotency_key"`
+	ObjectID       string         `json:"object_id,omitempty"`
+	Image          *CatalogObject `json:"image"`
+}
+
+// CreateCatalogImage uploads an image file, creating the catalog image object describing it.  When objectID
+// is set the image is attached to that catalog object.
+func (c *Client) CreateCatalogImage(ctx context.Context, idempotencyKey, objectID string, image *CatalogObject, filename, contentType string, file []byte) (*CatalogObject, error) {
+	request, err := json.Marshal(&createCatalogImageRequest{
+		IdempotencyKey: idempotencyKey,
+		ObjectID:       objectID,
+		Image:          image,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling request: %w", err)
+	}
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	requestPart, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {`form-data; name="request"`},
+		"Content-Type":        {"application/json"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating request part: %w", err)
+	}
+
+	if _, err := requestPart.Write(request); err != nil {
+		return nil, fmt.Errorf("error writing request part: %w", err)
+	}
+
+	filePart, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {fmt.Sprintf(`form-data; name="image_file"; filename=%q`, filename)},
+		"Content-Type":        {contentType},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating image part: %w", err)
+	}
+
+	if _, err := filePart.Write(file); err != nil {
+		return nil, fmt.Errorf("error writing image part: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("error closing multipart body: %w", err)
+	}
+
+	res := &struct {
+		Image *CatalogObject `json:"image"`
+	}{}
+
+	if err := c.send(ctx, http.MethodPost, "catalog/images", nil, body.Bytes(), w.FormDataContentType(), res); err != nil {
+		return nil, fmt.Errorf("error creating catalog image: %w", err)
+	}
+
+	return res.Image, nil
+}
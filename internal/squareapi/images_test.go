@@ -0,0 +1,67 @@
+package squareapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+)
+
+func TestCreateCatalogImage(t *testing.T) {
+	t.Parallel()
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/catalog/images" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("error parsing multipart body: %v", err)
+			return
+		}
+
+		req := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(r.FormValue("request")), &req); err != nil {
+			t.Errorf("error decoding request part: %v", err)
+		}
+
+		if req["idempotency_key"] != "key" || req["object_id"] != "item" {
+			t.Errorf("unexpected request part %v", req)
+		}
+
+		image, _ := req["image"].(map[string]interface{})
+		if data, _ := image["image_data"].(map[string]interface{}); data["caption"] != "caption" {
+			t.Errorf("unexpected image %v", image)
+		}
+
+		file, header, err := r.FormFile("image_file")
+		if err != nil {
+			t.Errorf("error reading image part: %v", err)
+			return
+		}
+
+		b, _ := io.ReadAll(file)
+		if string(b) != "contents" || header.Filename != "image.png" || header.Header.Get("Content-Type") != "image/png" {
+			t.Errorf("unexpected image part %s %v", b, header.Header)
+		}
+
+		_, _ = w.Write([]byte(`{"image": {"id": "image", "type": "IMAGE", "version": 1, "image_data": {"caption": "caption", "url": "https://example.com/image.png"}}}`))
+	})
+
+	image := NewCatalogObject(&objects.CatalogObject{
+		ID:   "#image",
+		Type: &objects.CatalogImage{Caption: "caption"},
+	})
+
+	res, err := c.CreateCatalogImage(context.Background(), "key", "item", image, "image.png", "image/png", []byte("contents"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if res.ID != "image" || res.Type.(*objects.CatalogImage).URL != "https://example.com/image.png" {
+		t.Fatalf("unexpected image %+v", res.CatalogObject)
+	}
+}
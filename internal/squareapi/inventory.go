@@ -0,0 +1,112 @@
+package squareapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+)
+
+// InventoryPhysicalCount mirrors objects.InventoryPhysicalCount, which can't be used as square-go misspells its
+// quantity field.  Quantities are decimal strings, as square sends them.
+type InventoryPhysicalCount struct {
+	ID                string `json:"id,omitempty"`
+	ReferenceID       string `json:"reference_id,omitempty"`
+	CatalogObjectID   string `json:"catalog_object_id,omitempty"`
+	CatalogObjectType string `json:"catalog_object_type,omitempty"`
+	State             string `json:"state,omitempty"`
+	LocationID        string `json:"location_id,omitempty"`
+	Quantity          string `json:"quantity,omitempty"`
+	OccurredAt        string `json:"occurred_at,omitempty"`
+	CreatedAt         string `json:"created_at,omitempty"`
+}
+
+// InventoryChange is a single change made by BatchChangeInventory.  Only physical counts are supported.
+type InventoryChange struct {
+	Type          string                  `json:"type,omitempty"`
+	PhysicalCount *InventoryPhysicalCount `json:"physical_count,omitempty"`
+}
+
+const InventoryChangeTypePhysicalCount = "PHYSICAL_COUNT"
+
+// InventoryCount is the calculated quantity of a catalog object in one state at one location.
+type InventoryCount struct {
+	CatalogObjectID   string `json:"catalog_object_id,omitempty"`
+	CatalogObjectType string `json:"catalog_object_type,omitempty"`
+	State             string `json:"state,omitempty"`
+	LocationID        string `json:"location_id,omitempty"`
+	Quantity          string `json:"quantity,omitempty"`
+	CalculatedAt      string `json:"calculated_at,omitempty"`
+}
+
+// InventoryCountFilter picks the counts returned by BatchRetrieveInventoryCounts.  Empty fields match
+// everything.
+type InventoryCountFilter struct {
+	CatalogObjectIDs []string `json:"catalog_object_ids,omitempty"`
+	LocationIDs      []string `json:"location_ids,omitempty"`
+	States           []string `json:"states,omitempty"`
+}
+
+// BatchChangeInventory applies changes atomically, returning the changes as square recorded them, in the same
+// order.
+func (c *Client) BatchChangeInventory(ctx context.Context, idempotencyKey string, changes []*InventoryChange) ([]*InventoryChange, error) {
+	res := &struct {
+		Changes []*InventoryChange `json:"changes"`
+	}{}
+
+	if err := c.Do(ctx, http.MethodPost, "inventory/changes/batch-create", nil, map[string]interface{}{
+		"idempotency_key":         idempotencyKey,
+		"changes":                 changes,
+		"ignore_unchanged_counts": false,
+	}, res); err != nil {
+		return nil, fmt.Errorf("error changing inventory: %w", err)
+	}
+
+	if len(res.Changes) != len(changes) {
+		return nil, fmt.Errorf("error changing inventory: expected %d changes in response, found %d", len(changes), len(res.Changes))
+	}
+
+	return res.Changes, nil
+}
+
+func (c *Client) RetrieveInventoryPhysicalCount(ctx context.Context, id string) (*InventoryPhysicalCount, error) {
+	res := &struct {
+		Count *InventoryPhysicalCount `json:"count"`
+	}{}
+
+	if err := c.Do(ctx, http.MethodGet, path.Join("inventory/physical-counts", id), nil, nil, res); err != nil {
+		return nil, fmt.Errorf("error retrieving inventory physical count: %w", err)
+	}
+
+	return res.Count, nil
+}
+
+// BatchRetrieveInventoryCounts returns every count matching filter, following pagination.
+func (c *Client) BatchRetrieveInventoryCounts(ctx context.Context, filter *InventoryCountFilter) ([]*InventoryCount, error) {
+	all := []*InventoryCount{}
+	cursor := ""
+
+	for {
+		req := &struct {
+			*InventoryCountFilter
+			Cursor string `json:"cursor,omitempty"`
+		}{filter, cursor}
+
+		res := &struct {
+			Counts []*InventoryCount `json:"counts"`
+			Cursor string            `json:"cursor"`
+		}{}
+
+		if err := c.Do(ctx, http.MethodPost, "inventory/counts/batch-retrieve", nil, req, res); err != nil {
+			return nil, fmt.Errorf("error retrieving inventory counts: %w", err)
+		}
+
+		all = append(all, res.Counts...)
+
+		if res.Cursor == "" {
+			return all, nil
+		}
+
+		cursor = res.Cursor
+	}
+}
@@ -0,0 +1,107 @@
+package squareapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/Houndie/square-go/objects"
+)
+
+// Location mirrors objects.Location, with business hours kept as the local time strings square uses, as
+// objects.BusinessHoursPeriod can't decode them.
+type Location struct {
+	ID            string               `json:"id,omitempty"`
+	Name          string               `json:"name,omitempty"`
+	Address       *objects.Address     `json:"address,omitempty"`
+	Timezone      string               `json:"timezone,omitempty"`
+	Status        string               `json:"status,omitempty"`
+	BusinessHours *BusinessHours       `json:"business_hours,omitempty"`
+	BusinessEmail string               `json:"business_email,omitempty"`
+	Description   string               `json:"description,omitempty"`
+	Coordinates   *objects.Coordinates `json:"coordinates,omitempty"`
+}
+
+type BusinessHours struct {
+	Periods []*BusinessHoursPeriod `json:"periods"`
+}
+
+type BusinessHoursPeriod struct {
+	DayOfWeek      string `json:"day_of_week,omitempty"`
+	StartLocalTime string `json:"start_local_time,omitempty"`
+	EndLocalTime   string `json:"end_local_time,omitempty"`
+}
+
+type locationResponse struct {
+	Location *Location `json:"location"`
+}
+
+func (c *Client) CreateLocation(ctx context.Context, location *Location) (*Location, error) {
+	res := &locationResponse{}
+
+	if err := c.Do(ctx, http.MethodPost, "locations", nil, map[string]interface{}{"location": location}, res); err != nil {
+		return nil, fmt.Errorf("error creating location: %w", err)
+	}
+
+	return res.Location, nil
+}
+
+func (c *Client) RetrieveLocation(ctx context.Context, id string) (*Location, error) {
+	res := &locationResponse{}
+
+	if err := c.Do(ctx, http.MethodGet, path.Join("locations", id), nil, nil, res); err != nil {
+		return nil, fmt.Errorf("error retrieving location: %w", err)
+	}
+
+	return res.Location, nil
+}
+
+// UpdateLocation makes a sparse update of a location.  Fields left empty in location are left unchanged,
+// unless they're named in clear, in which case they're removed.
+func (c *Client) UpdateLocation(ctx context.Context, id string, location *Location, clear []string) (*Location, error) {
+	body, err := withClearedFields(location, clear)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &locationResponse{}
+
+	if err := c.Do(ctx, http.MethodPut, path.Join("locations", id), nil, map[string]interface{}{"location": body}, res); err != nil {
+		return nil, fmt.Errorf("error updating location: %w", err)
+	}
+
+	return res.Location, nil
+}
+
+func (c *Client) ListLocations(ctx context.Context) ([]*Location, error) {
+	res := &struct {
+		Locations []*Location `json:"locations"`
+	}{}
+
+	if err := c.Do(ctx, http.MethodGet, "locations", nil, nil, res); err != nil {
+		return nil, fmt.Errorf("error listing locations: %w", err)
+	}
+
+	return res.Locations, nil
+}
+
+// withClearedFields converts v into a json object, with each of the clear fields set to null.
+func withClearedFields(v interface{}, clear []string) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling request: %w", err)
+	}
+
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("error unmarshalling request: %w", err)
+	}
+
+	for _, field := range clear {
+		m[field] = nil
+	}
+
+	return m, nil
+}
@@ -0,0 +1,118 @@
+package squareapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/Houndie/square-go/objects"
+)
+
+// TeamMember mirrors the team member object of square's team API, which square-go doesn't cover.
+type TeamMember struct {
+	ID                string                       `json:"id,omitempty"`
+	ReferenceID       string                       `json:"reference_id,omitempty"`
+	Status            string                       `json:"status,omitempty"`
+	GivenName         string                       `json:"given_name,omitempty"`
+	FamilyName        string                       `json:"family_name,omitempty"`
+	EmailAddress      string                       `json:"email_address,omitempty"`
+	PhoneNumber       string                       `json:"phone_number,omitempty"`
+	AssignedLocations *TeamMemberAssignedLocations `json:"assigned_locations,omitempty"`
+}
+
+const (
+	TeamMemberAssignmentTypeAll      = "ALL_CURRENT_AND_FUTURE_LOCATIONS"
+	TeamMemberAssignmentTypeExplicit = "EXPLICIT_LOCATIONS"
+)
+
+type TeamMemberAssignedLocations struct {
+	AssignmentType string   `json:"assignment_type,omitempty"`
+	LocationIDs    []string `json:"location_ids,omitempty"`
+}
+
+// WageSetting is the pay of a team member for each of their jobs.  Job assignments are always sent, so that
+// an empty list removes them all.
+type WageSetting struct {
+	TeamMemberID     string           `json:"team_member_id,omitempty"`
+	JobAssignments   []*JobAssignment `json:"job_assignments"`
+	IsOvertimeExempt bool             `json:"is_overtime_exempt"`
+	Version          int64            `json:"version,omitempty"`
+}
+
+type JobAssignment struct {
+	JobTitle    string         `json:"job_title,omitempty"`
+	PayType     string         `json:"pay_type,omitempty"`
+	HourlyRate  *objects.Money `json:"hourly_rate,omitempty"`
+	AnnualRate  *objects.Money `json:"annual_rate,omitempty"`
+	WeeklyHours int            `json:"weekly_hours,omitempty"`
+}
+
+type teamMemberResponse struct {
+	TeamMember *TeamMember `json:"team_member"`
+}
+
+type wageSettingResponse struct {
+	WageSetting *WageSetting `json:"wage_setting"`
+}
+
+func (c *Client) CreateTeamMember(ctx context.Context, idempotencyKey string, teamMember *TeamMember) (*TeamMember, error) {
+	res := &teamMemberResponse{}
+
+	if err := c.Do(ctx, http.MethodPost, "team-members", nil, map[string]interface{}{
+		"idempotency_key": idempotencyKey,
+		"team_member":     teamMember,
+	}, res); err != nil {
+		return nil, fmt.Errorf("error creating team member: %w", err)
+	}
+
+	return res.TeamMember, nil
+}
+
+func (c *Client) RetrieveTeamMember(ctx context.Context, id string) (*TeamMember, error) {
+	res := &teamMemberResponse{}
+
+	if err := c.Do(ctx, http.MethodGet, path.Join("team-members", id), nil, nil, res); err != nil {
+		return nil, fmt.Errorf("error retrieving team member: %w", err)
+	}
+
+	return res.TeamMember, nil
+}
+
+// UpdateTeamMember makes a sparse update of a team member.  Fields left empty in teamMember are left
+// unchanged, unless they're named in clear, in which case they're removed.
+func (c *Client) UpdateTeamMember(ctx context.Context, id string, teamMember *TeamMember, clear []string) (*TeamMember, error) {
+	body, err := withClearedFields(teamMember, clear)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &teamMemberResponse{}
+
+	if err := c.Do(ctx, http.MethodPut, path.Join("team-members", id), nil, map[string]interface{}{"team_member": body}, res); err != nil {
+		return nil, fmt.Errorf("error updating team member: %w", err)
+	}
+
+	return res.TeamMember, nil
+}
+
+func (c *Client) RetrieveWageSetting(ctx context.Context, teamMemberID string) (*WageSetting, error) {
+	res := &wageSettingResponse{}
+
+	if err := c.Do(ctx, http.MethodGet, path.Join("team-members", teamMemberID, "wage-setting"), nil, nil, res); err != nil {
+		return nil, fmt.Errorf("error retrieving wage setting: %w", err)
+	}
+
+	return res.WageSetting, nil
+}
+
+// UpdateWageSetting replaces a team member's wage setting, creating it if they don't have one yet.
+func (c *Client) UpdateWageSetting(ctx context.Context, teamMemberID string, wageSetting *WageSetting) (*WageSetting, error) {
+	res := &wageSettingResponse{}
+
+	if err := c.Do(ctx, http.MethodPut, path.Join("team-members", teamMemberID, "wage-setting"), nil, map[string]interface{}{"wage_setting": wageSetting}, res); err != nil {
+		return nil, fmt.Errorf("error updating wage setting: %w", err)
+	}
+
+	return res.WageSetting, nil
+}
@@ -3,17 +3,101 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/Houndie/square-go"
 	"github.com/Houndie/square-go/catalog"
 	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/square-go/options"
+	"github.com/Houndie/terraform-provider-square/internal/fakesquare"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+// testFakeToken is the access token accepted by the fake square server.
+const testFakeToken = "fake-token"
+
+// testBaseURL is the base url of the fake square server, or empty when testing against the sandbox.
+var testBaseURL string
+
 func TestMain(m *testing.M) {
-	resource.TestMain(m)
+	if os.Getenv("TEST_TOKEN") != "" {
+		resource.TestMain(m)
+		return
+	}
+
+	// Sweepers only run against the sandbox, so the tests are run directly.  os.Exit skips deferred calls, so
+	// the server is closed before exiting.
+	server := fakesquare.NewServer(testFakeToken)
+	testBaseURL = server.URL
+
+	code := m.Run()
+
+	server.Close()
+	os.Exit(code)
+}
+
+// testAccToken returns the token acceptance tests should use, which is TEST_TOKEN when set, or the fake
+// server's token otherwise.
+func testAccToken() string {
+	if token := os.Getenv("TEST_TOKEN"); token != "" {
+		return token
+	}
+
+	return testFakeToken
+}
+
+// testHTTPClient creates an http client pointed at the fake square server when not testing against the
+// sandbox.
+func testHTTPClient() (*http.Client, error) {
+	httpClient := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	if testBaseURL != "" {
+		transport, err := newBaseURLTransport(testBaseURL, http.DefaultTransport)
+		if err != nil {
+			return nil, err
+		}
+
+		httpClient.Transport = transport
+	}
+
+	return httpClient, nil
+}
+
+// testSquareClient creates a client for inspecting remote state.
+func testSquareClient(apiKey string) (*square.Client, error) {
+	httpClient, err := testHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := square.NewClient(apiKey, objects.Sandbox, options.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("error creating square client: %w", err)
+	}
+
+	return client, nil
+}
+
+// testSquareAPIClient creates a client for inspecting remote state that square-go doesn't cover.
+func testSquareAPIClient(apiKey string) (*squareapi.Client, error) {
+	httpClient, err := testHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := squareapi.NewClient(apiKey, objects.Sandbox, httpClient, -1)
+	if err != nil {
+		return nil, fmt.Errorf("error creating square api client: %w", err)
+	}
+
+	return client, nil
 }
 
 func init() {
@@ -25,7 +109,7 @@ func init() {
 				return fmt.Errorf("Cannot sweep, test token not set")
 			}
 
-			client, err := square.NewClient(token, objects.Sandbox)
+			client, err := testSquareClient(token)
 			if err != nil {
 				return fmt.Errorf("error creating square client in sweeper: %w", err)
 			}
@@ -54,3 +138,27 @@ func init() {
 		},
 	})
 }
+
+// deleteCatalogObjectRemote deletes a resource's catalog object behind terraform's back, to simulate
+// someone removing it from the Square dashboard.
+func deleteCatalogObjectRemote(resourceName, apiKey string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		client, err := testSquareClient(apiKey)
+		if err != nil {
+			return fmt.Errorf("error creating square client: %w", err)
+		}
+
+		if _, err := client.Catalog.DeleteObject(context.Background(), &catalog.DeleteObjectRequest{
+			ObjectID: rs.Primary.ID,
+		}); err != nil {
+			return fmt.Errorf("error deleting remote object: %w", err)
+		}
+
+		return nil
+	}
+}
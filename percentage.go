@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// percentageRegexp matches the decimal strings square uses for percentages, such as "7.25".
+var percentageRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+func validatePercentage(i interface{}, path cty.Path) diag.Diagnostics {
+	percentage, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "expected percentage to be a string",
+			AttributePath: path,
+		}}
+	}
+
+	if !percentageRegexp.MatchString(percentage) {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("invalid percentage %q", percentage),
+			Detail:        "percentage must be a decimal number, such as 7 or 7.25",
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
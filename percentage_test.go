@@ -0,0 +1,23 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestValidatePercentage(t *testing.T) {
+	t.Parallel()
+
+	for _, percentage := range []string{"0", "7", "7.25", "100.0"} {
+		if diags := validatePercentage(percentage, cty.Path{}); diags.HasError() {
+			t.Fatalf("unexpected error validating %s: %v", percentage, diags)
+		}
+	}
+
+	for _, percentage := range []string{"", "7%", ".5", "7.", "-5", "1e3", "seven"} {
+		if diags := validatePercentage(percentage, cty.Path{}); !diags.HasError() {
+			t.Fatalf("expected error validating %q", percentage)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withLocationPresence adds the attributes controlling which locations a catalog object is present at to
+// a resource schema.
+func withLocationPresence(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["present_at_all_locations"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	}
+	s["present_at_location_ids"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	s["absent_at_location_ids"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+
+	return s
+}
+
+// locationPresence is where a catalog object is present.  When presentAtAll is set, the object is present
+// everywhere except absentAt, otherwise it is present only at presentAt.
+type locationPresence struct {
+	presentAtAll bool
+	presentAt    []string
+	absentAt     []string
+}
+
+// locationPresenceFromResource reads location presence using get, which is either the Get method of
+// resource data, or a lookup into a nested block.
+func locationPresenceFromResource(get func(string) interface{}) *locationPresence {
+	return &locationPresence{
+		presentAtAll: get("present_at_all_locations").(bool),
+		presentAt:    stringSet(get("present_at_location_ids")),
+		absentAt:     stringSet(get("absent_at_location_ids")),
+	}
+}
+
+func stringSet(v interface{}) []string {
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	s := []string{}
+
+	for _, e := range set.List() {
+		if str, ok := e.(string); ok && str != "" {
+			s = append(s, str)
+		}
+	}
+
+	sort.Strings(s)
+
+	return s
+}
+
+func (p *locationPresence) apply(o *objects.CatalogObject) {
+	o.PresentAtAllLocations = p.presentAtAll
+	o.PresentAtLocationIDs = p.presentAt
+	o.AbsentAtLocationIDs = p.absentAt
+}
+
+// locationPresenceToMap converts the location presence of o into its terraform representation.
+func locationPresenceToMap(o *objects.CatalogObject) map[string]interface{} {
+	return map[string]interface{}{
+		"present_at_all_locations": o.PresentAtAllLocations,
+		"present_at_location_ids":  schema.NewSet(schema.HashString, stringsToInterfaces(o.PresentAtLocationIDs)),
+		"absent_at_location_ids":   schema.NewSet(schema.HashString, stringsToInterfaces(o.AbsentAtLocationIDs)),
+	}
+}
+
+func stringsToInterfaces(s []string) []interface{} {
+	i := make([]interface{}, len(s))
+	for j, str := range s {
+		i[j] = str
+	}
+
+	return i
+}
+
+func setLocationPresence(o *objects.CatalogObject, d *schema.ResourceData) error {
+	for k, v := range locationPresenceToMap(o) {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("error setting %s: %w", k, err)
+		}
+	}
+
+	return nil
+}
+
+// validate checks that the location presence doesn't contradict itself.
+func (p *locationPresence) validate() error {
+	if p.presentAtAll && len(p.presentAt) > 0 {
+		return fmt.Errorf("present_at_location_ids can't be set when present_at_all_locations is true, use absent_at_location_ids to exclude locations instead")
+	}
+
+	if !p.presentAtAll && len(p.absentAt) > 0 {
+		return fmt.Errorf("absent_at_location_ids can't be set when present_at_all_locations is false, use present_at_location_ids to pick locations instead")
+	}
+
+	return nil
+}
+
+// validateWithin checks that an object isn't explicitly placed at any location where its parent is not
+// present.
+func (p *locationPresence) validateWithin(parent *locationPresence) error {
+	if p.presentAtAll {
+		return nil
+	}
+
+	outside := []string{}
+
+	for _, id := range p.presentAt {
+		if !parent.presentAtLocation(id) {
+			outside = append(outside, id)
+		}
+	}
+
+	if len(outside) > 0 {
+		return fmt.Errorf("present at locations %s, where the item is not present", strings.Join(outside, ", "))
+	}
+
+	return nil
+}
+
+func (p *locationPresence) presentAtLocation(id string) bool {
+	if p.presentAtAll {
+		return !containsString(p.absentAt, id)
+	}
+
+	return containsString(p.presentAt, id)
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// customizeDiffLocationPresence checks that the location presence of a catalog object is coherent.
+func customizeDiffLocationPresence(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("present_at_location_ids") || !d.NewValueKnown("absent_at_location_ids") {
+		return nil
+	}
+
+	return locationPresenceFromResource(d.Get).validate()
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestLocationPresenceValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		presence *locationPresence
+		valid    bool
+	}{
+		"all locations": {
+			presence: &locationPresence{presentAtAll: true},
+			valid:    true,
+		},
+		"all locations except some": {
+			presence: &locationPresence{presentAtAll: true, absentAt: []string{"a"}},
+			valid:    true,
+		},
+		"some locations": {
+			presence: &locationPresence{presentAt: []string{"a"}},
+			valid:    true,
+		},
+		"all locations and some locations": {
+			presence: &locationPresence{presentAtAll: true, presentAt: []string{"a"}},
+			valid:    false,
+		},
+		"absent from some locations of none": {
+			presence: &locationPresence{absentAt: []string{"a"}},
+			valid:    false,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := test.presence.validate(); (err == nil) != test.valid {
+				t.Fatalf("expected valid to be %t, found error %v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestLocationPresenceValidateWithin(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		presence *locationPresence
+		parent   *locationPresence
+		valid    bool
+	}{
+		"everywhere": {
+			presence: &locationPresence{presentAtAll: true},
+			parent:   &locationPresence{presentAt: []string{"a"}},
+			valid:    true,
+		},
+		"within parent locations": {
+			presence: &locationPresence{presentAt: []string{"a"}},
+			parent:   &locationPresence{presentAt: []string{"a", "b"}},
+			valid:    true,
+		},
+		"outside parent locations": {
+			presence: &locationPresence{presentAt: []string{"a", "c"}},
+			parent:   &locationPresence{presentAt: []string{"a", "b"}},
+			valid:    false,
+		},
+		"at location parent is absent from": {
+			presence: &locationPresence{presentAt: []string{"a"}},
+			parent:   &locationPresence{presentAtAll: true, absentAt: []string{"a"}},
+			valid:    false,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := test.presence.validateWithin(test.parent); (err == nil) != test.valid {
+				t.Fatalf("expected valid to be %t, found error %v", test.valid, err)
+			}
+		})
+	}
+}
@@ -4,22 +4,35 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
-	"github.com/Houndie/square-go"
 	"github.com/Houndie/square-go/objects"
-	"github.com/Houndie/square-go/options"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
-	ProviderAccessToken  = "access_token"
-	ProviderEnvironment  = "environment"
-	ProviderTimeout      = "timeout"
-	ProviderMaxRetryTime = "max_retry_time_seconds"
+	ProviderAccessToken           = "access_token"
+	ProviderEnvironment           = "environment"
+	ProviderTimeout               = "timeout"
+	ProviderMaxRetryTime          = "max_retry_time_seconds"
+	ProviderDefaultCurrency       = "default_currency"
+	ProviderBaseURL               = "base_url"
+	ProviderRetryVersionConflicts = "retry_version_conflicts"
 )
 
+type providerMeta struct {
+	api                   *squareapi.Client
+	catalog               *catalogBatcher
+	cache                 *catalogCache
+	defaultCurrency       string
+	retryVersionConflicts bool
+}
+
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
@@ -42,10 +55,50 @@ func Provider() *schema.Provider {
 				Optional: true,
 				Default:  -1,
 			},
+			ProviderDefaultCurrency: &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "USD",
+				ValidateDiagFunc: validateCurrency,
+			},
+			ProviderBaseURL: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			ProviderRetryVersionConflicts: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"square_catalog_item":     resourceCatalogItem(),
-			"square_catalog_discount": resourceCatalogDiscount(),
+			"square_catalog_item":                         resourceCatalogItem(),
+			"square_catalog_discount":                     resourceCatalogDiscount(),
+			"square_catalog_category":                     resourceCatalogCategory(),
+			"square_catalog_tax":                          resourceCatalogTax(),
+			"square_catalog_modifier_list":                resourceCatalogModifierList(),
+			"square_catalog_image":                        resourceCatalogImage(),
+			"square_catalog_item_option":                  resourceCatalogItemOption(),
+			"square_catalog_pricing_rule":                 resourceCatalogPricingRule(),
+			"square_catalog_product_set":                  resourceCatalogProductSet(),
+			"square_catalog_time_period":                  resourceCatalogTimePeriod(),
+			"square_location":                             resourceLocation(),
+			"square_customer":                             resourceCustomer(),
+			"square_customer_custom_attribute":            resourceCustomerCustomAttribute(),
+			"square_customer_custom_attribute_definition": resourceCustomerCustomAttributeDefinition(),
+			"square_customer_group":                       resourceCustomerGroup(),
+			"square_customer_group_membership":            resourceCustomerGroupMembership(),
+			"square_inventory_physical_count":             resourceInventoryPhysicalCount(),
+			"square_team_member":                          resourceTeamMember(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"square_catalog_item":     dataSourceCatalogItem(),
+			"square_catalog_discount": dataSourceCatalogDiscount(),
+			"square_catalog_object":   dataSourceCatalogObject(),
+			"square_location":         dataSourceLocation(),
+			"square_locations":        dataSourceLocations(),
+			"square_customer":         dataSourceCustomer(),
+			"square_inventory_count":  dataSourceInventoryCount(),
 		},
 		ConfigureContextFunc: func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 			var environment objects.Environment
@@ -58,22 +111,72 @@ func Provider() *schema.Provider {
 				return nil, diag.Errorf("unknown provider environment: %s", d.Get(ProviderEnvironment).(string))
 			}
 
-			o := []options.ClientOption{
-				options.WithHTTPClient(&http.Client{
-					Timeout: time.Duration(d.Get(ProviderTimeout).(int)) * time.Second, //nolint:durationcheck
-				}),
+			httpClient := &http.Client{
+				Timeout: time.Duration(d.Get(ProviderTimeout).(int)) * time.Second, //nolint:durationcheck
+			}
+
+			if baseURL := d.Get(ProviderBaseURL).(string); baseURL != "" {
+				transport, err := newBaseURLTransport(baseURL, http.DefaultTransport)
+				if err != nil {
+					return nil, diag.FromErr(err)
+				}
+
+				httpClient.Transport = transport
 			}
 
+			maxRetryTime := time.Duration(-1)
 			if t := d.Get(ProviderMaxRetryTime).(int); t != -1 {
-				o = append(o, options.WithRateLimit(time.Duration(t)*time.Second))
+				maxRetryTime = time.Duration(t) * time.Second
 			}
 
-			client, err := square.NewClient(d.Get(ProviderAccessToken).(string), environment, o...)
+			api, err := squareapi.NewClient(d.Get(ProviderAccessToken).(string), environment, httpClient, maxRetryTime)
 			if err != nil {
-				return nil, diag.FromErr(fmt.Errorf("error creating square client: %w", err))
+				return nil, diag.FromErr(fmt.Errorf("error creating square api client: %w", err))
 			}
 
-			return client, nil
+			return &providerMeta{
+				api:                   api,
+				catalog:               newCatalogBatcher(api, catalogBatchWindow),
+				cache:                 newCatalogCache(api),
+				defaultCurrency:       d.Get(ProviderDefaultCurrency).(string),
+				retryVersionConflicts: d.Get(ProviderRetryVersionConflicts).(bool),
+			}, nil
 		},
 	}
 }
+
+// squareAPIPath is the path prefix of the Square API, which is replaced by the path of base_url when set.
+const squareAPIPath = "/v2"
+
+// baseURLTransport redirects requests meant for the Square API to another server, such as a local fake.
+type baseURLTransport struct {
+	baseURL *url.URL
+	wrap    http.RoundTripper
+}
+
+func newBaseURLTransport(baseURL string, wrap http.RoundTripper) (*baseURLTransport, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing base url: %w", err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("base url %s must include a scheme and host", baseURL)
+	}
+
+	return &baseURLTransport{
+		baseURL: u,
+		wrap:    wrap,
+	}, nil
+}
+
+func (t *baseURLTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.baseURL.Scheme
+	r.URL.Host = t.baseURL.Host
+	r.URL.Path = path.Join(t.baseURL.Path, strings.TrimPrefix(r.URL.Path, squareAPIPath))
+	r.URL.RawPath = ""
+	r.Host = t.baseURL.Host
+
+	return t.wrap.RoundTrip(r) //nolint:wrapcheck
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestProvider(t *testing.T) {
+	t.Parallel()
+
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("error validating provider: %v", err)
+	}
+}
+
+func TestBaseURLTransport(t *testing.T) {
+	t.Parallel()
+
+	var found *http.Request
+
+	transport, err := newBaseURLTransport("http://127.0.0.1:1234/fake/v2", roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		found = r
+
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+	if err != nil {
+		t.Fatalf("error creating transport: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://connect.squareup.com/v2/catalog/list?types=ITEM", nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("error making request: %v", err)
+	}
+
+	if got := found.URL.String(); got != "http://127.0.0.1:1234/fake/v2/catalog/list?types=ITEM" {
+		t.Fatalf("unexpected rewritten url %s", got)
+	}
+
+	if req.URL.Host != "connect.squareup.com" {
+		t.Fatalf("original request was modified")
+	}
+
+	if _, err := newBaseURLTransport("not-a-url", http.DefaultTransport); err == nil {
+		t.Fatalf("expected error for base url without scheme and host")
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// testProviderMeta configures the provider, with any extra settings in config, against the fake square server started in TestMain, so
+// resource functions can be exercised without terraform or network access.
+func testProviderMeta(t *testing.T, config map[string]interface{}) *providerMeta {
+	t.Helper()
+
+	if testBaseURL == "" {
+		t.Skip("TEST_TOKEN is set, skipping tests that require the fake square server")
+	}
+
+	p := Provider()
+
+	raw := map[string]interface{}{
+		ProviderAccessToken: testFakeToken,
+		ProviderBaseURL:     testBaseURL,
+	}
+	for k, v := range config {
+		raw[k] = v
+	}
+
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+
+	meta, ok := p.Meta().(*providerMeta)
+	if !ok {
+		t.Fatalf("unexpected provider meta type %T", p.Meta())
+	}
+
+	return meta
+}
+
+// testApply plans and applies config against state, the way terraform would for a single resource.
+func testApply(t *testing.T, r *schema.Resource, meta *providerMeta, state *terraform.InstanceState, config map[string]interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning resource: %v", err)
+	}
+
+	return r.Apply(context.Background(), state, diff, meta)
+}
+
+// testReadDataSource reads a data source with config, the way terraform would.
+func testReadDataSource(t *testing.T, r *schema.Resource, meta *providerMeta, config map[string]interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning data source: %v", err)
+	}
+
+	return r.ReadDataApply(context.Background(), diff, meta)
+}
+
+func TestProviderBaseURL(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogCategory()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "base-url-category",
+	})
+
+	if diags := r.CreateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("error creating category: %v", diags)
+	}
+
+	if d.Id() == "" || strings.HasPrefix(d.Id(), "#") {
+		t.Fatalf("no id assigned from the server")
+	}
+
+	if _, err := meta.api.DeleteCatalogObject(context.Background(), d.Id()); err != nil {
+		t.Fatalf("error deleting category out of band: %v", err)
+	}
+
+	if diags := r.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("error reading deleted category: %v", diags)
+	}
+
+	if d.Id() != "" {
+		t.Fatalf("expected deleted category to be removed from state")
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCatalogCategory() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		CreateContext: resourceCatalogUpsert(catalogCategoryResourceToObject, catalogCategoryObjectToResource),
+		ReadContext:   resourceCatalogRead(catalogCategoryObjectToResource),
+		UpdateContext: resourceCatalogUpsert(catalogCategoryResourceToObject, catalogCategoryObjectToResource),
+		DeleteContext: resourceCatalogDelete(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCatalogImport(catalogCategoryObjectToResource),
+		},
+	}
+}
+
+func catalogCategoryResourceToObject(d *schema.ResourceData, meta *providerMeta) (*squareapi.CatalogObject, error) {
+	id := d.Id()
+	if id == "" {
+		id = "#id"
+	}
+
+	return squareapi.NewCatalogObject(&objects.CatalogObject{
+		ID: id,
+		Type: &objects.CatalogCategory{
+			Name: d.Get("name").(string),
+		},
+		Version:               d.Get("version").(int),
+		PresentAtAllLocations: true,
+	}), nil
+}
+
+func catalogCategoryObjectToResource(o *squareapi.CatalogObject, d *schema.ResourceData, meta *providerMeta) error {
+	d.SetId(o.ID)
+
+	category, ok := o.Type.(*objects.CatalogCategory)
+	if !ok {
+		return fmt.Errorf("catalog object is not a catalog category")
+	}
+
+	if err := d.Set("name", category.Name); err != nil {
+		return fmt.Errorf("error setting name: %w", err)
+	}
+
+	if err := d.Set("version", o.Version); err != nil {
+		return fmt.Errorf("error setting version: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Houndie/square-go/catalog"
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const catalogCategoryBlock = `resource "square_catalog_category" "test_category" {
+	name = "my-category"
+}
+
+`
+
+const catalogCategoryItemBlock = `resource "square_catalog_item" "test_category_item" {
+	name = "my-category-item"
+	category_id = square_catalog_category.test_category.id
+
+	variation {
+		name = "variation1"
+		pricing_type = "VARIABLE_PRICING"
+	}
+}
+
+`
+
+func TestCatalogCategory(t *testing.T) {
+	t.Parallel()
+
+	token := testAccToken()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"square": func() (*schema.Provider, error) { return Provider(), nil }, //nolint:unparam
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerBlock(token) + catalogCategoryBlock,
+				Check: resource.ComposeTestCheckFunc(
+					checkCatalogCategory("square_catalog_category.test_category", &objects.CatalogObject{
+						Type: &objects.CatalogCategory{
+							Name: "my-category",
+						},
+					}),
+					checkCatalogCategoryRemote("square_catalog_category.test_category", token),
+				),
+			},
+			{
+				Config: providerBlock(token) + catalogCategoryBlock + catalogCategoryItemBlock,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"square_catalog_item.test_category_item", "category_id",
+						"square_catalog_category.test_category", "id",
+					),
+					checkCatalogItemRemote("square_catalog_item.test_category_item", token),
+				),
+			},
+			{
+				Config: providerBlock(token),
+				Check: resource.ComposeTestCheckFunc(
+					checkCatalogCategoryDoesntExist("square_catalog_category.test_category"),
+					checkCatalogCategoryDoesntExistRemote("my-category", token),
+				),
+			},
+		},
+	})
+}
+
+func checkCatalogCategory(resourceName string, expected *objects.CatalogObject) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("Widget ID is not set")
+		}
+
+		return compareCatalogCategoryToResource(rs.Primary, expected)
+	}
+}
+
+func compareCatalogCategoryToResource(d *terraform.InstanceState, s *objects.CatalogObject) error {
+	if s.ID != "" && d.ID != s.ID {
+		return fmt.Errorf("unexpected id")
+	}
+
+	if strings.HasPrefix(d.ID, "#") {
+		return fmt.Errorf("no id assigned from server")
+	}
+
+	category, ok := s.Type.(*objects.CatalogCategory)
+	if !ok {
+		return fmt.Errorf("provided catalog object is not a category")
+	}
+
+	if d.Attributes["name"] != category.Name {
+		return fmt.Errorf("unexpected name")
+	}
+
+	return nil
+}
+
+func checkCatalogCategoryDoesntExist(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		_, ok := s.RootModule().Resources[resourceName]
+		if ok {
+			return fmt.Errorf("Found: %s", resourceName)
+		}
+
+		return nil
+	}
+}
+
+//nolint:dupl
+func checkCatalogCategoryDoesntExistRemote(categoryName, apiKey string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := testSquareClient(apiKey)
+		if err != nil {
+			return fmt.Errorf("error creating square client: %w", err)
+		}
+
+		res, err := client.Catalog.List(context.Background(), &catalog.ListRequest{
+			Types: []objects.CatalogObjectEnumType{objects.CatalogObjectEnumTypeCategory},
+		})
+		if err != nil {
+			return fmt.Errorf("error listing all catalog categories: %w", err)
+		}
+
+		for res.Objects.Next() {
+			v := res.Objects.Value()
+
+			category, ok := v.Object.Type.(*objects.CatalogCategory)
+			if !ok {
+				return fmt.Errorf("object is not a catalog category")
+			}
+
+			if category.Name == categoryName {
+				return fmt.Errorf("found catalog category that should be deleted")
+			}
+		}
+
+		return nil
+	}
+}
+
+func checkCatalogCategoryRemote(resourceName, apiKey string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		client, err := testSquareClient(apiKey)
+		if err != nil {
+			return fmt.Errorf("error creating square client: %w", err)
+		}
+
+		res, err := client.Catalog.RetrieveObject(context.Background(), &catalog.RetrieveObjectRequest{
+			ObjectID: rs.Primary.ID,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error retrieving remote object: %w", err)
+		}
+
+		return compareCatalogCategoryToResource(rs.Primary, res.Object)
+	}
+}
@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCatalogDiscount() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withLocationPresence(map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
@@ -17,24 +21,40 @@ func resourceCatalogDiscount() *schema.Resource {
 			"type": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					catalogDiscountFixedAmount,
+					catalogDiscountVariableAmount,
+					catalogDiscountFixedPercentage,
+					catalogDiscountVariablePercentage,
+				}, false)),
 			},
 			"percentage": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validatePercentage,
 			},
 			"amount": &schema.Schema{
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"currency": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateCurrency,
+			},
 			"version": &schema.Schema{
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
-		},
+		}),
+		CustomizeDiff: customdiff.All(customizeDiffLocationPresence, customizeDiffCatalogDiscount),
 		CreateContext: resourceCatalogUpsert(catalogDiscountResourceToObject, catalogDiscountObjectToResource),
 		ReadContext:   resourceCatalogRead(catalogDiscountObjectToResource),
 		UpdateContext: resourceCatalogUpsert(catalogDiscountResourceToObject, catalogDiscountObjectToResource),
 		DeleteContext: resourceCatalogDelete(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCatalogImport(catalogDiscountObjectToResource),
+		},
 	}
 }
 
@@ -45,7 +65,42 @@ const (
 	catalogDiscountVariablePercentage = "VARIABLE_PERCENTAGE"
 )
 
-func catalogDiscountResourceToObject(d *schema.ResourceData) (*objects.CatalogObject, error) {
+// customizeDiffCatalogDiscount checks that the percentage, amount, and currency set are the ones used by the
+// discount's type.
+func customizeDiffCatalogDiscount(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+
+	discountType := d.Get("type").(string)
+
+	switch discountType {
+	case catalogDiscountFixedPercentage, catalogDiscountVariablePercentage:
+		if d.NewValueKnown("percentage") && d.Get("percentage").(string) == "" {
+			return fmt.Errorf("percentage required with a type of %s", discountType)
+		}
+
+		if d.NewValueKnown("amount") && d.Get("amount").(int) != 0 {
+			return fmt.Errorf("amount can't be set with a type of %s", discountType)
+		}
+
+		if d.NewValueKnown("currency") && d.Get("currency").(string) != "" {
+			return fmt.Errorf("currency can't be set with a type of %s", discountType)
+		}
+	case catalogDiscountFixedAmount, catalogDiscountVariableAmount:
+		if d.NewValueKnown("amount") && d.Get("amount").(int) == 0 {
+			return fmt.Errorf("amount required with a type of %s", discountType)
+		}
+
+		if d.NewValueKnown("percentage") && d.Get("percentage").(string) != "" {
+			return fmt.Errorf("percentage can't be set with a type of %s", discountType)
+		}
+	}
+
+	return nil
+}
+
+func catalogDiscountResourceToObject(d *schema.ResourceData, meta *providerMeta) (*squareapi.CatalogObject, error) {
 	id := d.Id()
 	if id == "" {
 		id = "#id"
@@ -81,7 +136,7 @@ func catalogDiscountResourceToObject(d *schema.ResourceData) (*objects.CatalogOb
 		discountType = &objects.CatalogDiscountFixedAmount{
 			AmountMoney: &objects.Money{
 				Amount:   amount,
-				Currency: "USD",
+				Currency: meta.currency(d.Get("currency").(string)),
 			},
 		}
 	case catalogDiscountVariableAmount:
@@ -93,22 +148,28 @@ func catalogDiscountResourceToObject(d *schema.ResourceData) (*objects.CatalogOb
 		discountType = &objects.CatalogDiscountVariableAmount{
 			AmountMoney: &objects.Money{
 				Amount:   amount,
-				Currency: "USD",
+				Currency: meta.currency(d.Get("currency").(string)),
 			},
 		}
+	default:
+		return nil, fmt.Errorf("unknown discount type %s", d.Get("type"))
 	}
 
-	return &objects.CatalogObject{
+	discount := &objects.CatalogObject{
 		ID: id,
 		Type: &objects.CatalogDiscount{
 			Name:         d.Get("name").(string),
 			DiscountType: discountType,
 		},
 		Version: d.Get("version").(int),
-	}, nil
+	}
+
+	locationPresenceFromResource(d.Get).apply(discount)
+
+	return squareapi.NewCatalogObject(discount), nil
 }
 
-func catalogDiscountObjectToResource(o *objects.CatalogObject, d *schema.ResourceData) error {
+func catalogDiscountObjectToResource(o *squareapi.CatalogObject, d *schema.ResourceData, meta *providerMeta) error {
 	d.SetId(o.ID)
 
 	discount, ok := o.Type.(*objects.CatalogDiscount)
@@ -145,6 +206,10 @@ func catalogDiscountObjectToResource(o *objects.CatalogObject, d *schema.Resourc
 		if err := d.Set("amount", t.AmountMoney.Amount); err != nil {
 			return fmt.Errorf("error setting fixed amount amount")
 		}
+
+		if err := d.Set("currency", meta.stateCurrency(t.AmountMoney.Currency, d.Get("currency").(string))); err != nil {
+			return fmt.Errorf("error setting fixed amount currency")
+		}
 	case *objects.CatalogDiscountVariableAmount:
 		if err := d.Set("type", catalogDiscountVariableAmount); err != nil {
 			return fmt.Errorf("error setting variable amount type")
@@ -153,6 +218,14 @@ func catalogDiscountObjectToResource(o *objects.CatalogObject, d *schema.Resourc
 		if err := d.Set("amount", t.AmountMoney.Amount); err != nil {
 			return fmt.Errorf("error setting variable amount amount")
 		}
+
+		if err := d.Set("currency", meta.stateCurrency(t.AmountMoney.Currency, d.Get("currency").(string))); err != nil {
+			return fmt.Errorf("error setting variable amount currency")
+		}
+	}
+
+	if err := setLocationPresence(o.CatalogObject, d); err != nil {
+		return err
 	}
 
 	if err := d.Set("version", o.Version); err != nil {
@@ -3,17 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
-	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"testing"
-	"time"
 
-	"github.com/Houndie/square-go"
 	"github.com/Houndie/square-go/catalog"
 	"github.com/Houndie/square-go/objects"
-	"github.com/Houndie/square-go/options"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -38,11 +33,7 @@ const catalogDiscountBlock2 = `resource "square_catalog_discount" "test_discount
 func TestCatalogDiscount(t *testing.T) {
 	t.Parallel()
 
-	token := os.Getenv("TEST_TOKEN")
-	if token == "" {
-		t.Log("Test skipped as TEST_TOKEN not set")
-		t.Skip()
-	}
+	token := testAccToken()
 
 	resource.Test(t, resource.TestCase{
 		ProviderFactories: map[string]func() (*schema.Provider, error){
@@ -65,6 +56,11 @@ func TestCatalogDiscount(t *testing.T) {
 					checkCatalogDiscountRemote("square_catalog_discount.test_discount_1", token),
 				),
 			},
+			{
+				ResourceName:      "square_catalog_discount.test_discount_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			{
 				Config: providerBlock(token) + catalogDiscountBlock2,
 				Check: resource.ComposeTestCheckFunc(
@@ -189,9 +185,7 @@ func checkCatalogDiscountDoesntExist(resourceName string) resource.TestCheckFunc
 //nolint:dupl
 func checkCatalogDiscountDoesntExistRemote(itemName, apiKey string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client, err := square.NewClient(apiKey, objects.Sandbox, options.WithHTTPClient(&http.Client{
-			Timeout: 10 * time.Second,
-		}))
+		client, err := testSquareClient(apiKey)
 		if err != nil {
 			return fmt.Errorf("error creating square client: %w", err)
 		}
@@ -228,9 +222,7 @@ func checkCatalogDiscountRemote(resourceName, apiKey string) resource.TestCheckF
 			return fmt.Errorf("Not found: %s", resourceName)
 		}
 
-		client, err := square.NewClient(apiKey, objects.Sandbox, options.WithHTTPClient(&http.Client{
-			Timeout: 10 * time.Second,
-		}))
+		client, err := testSquareClient(apiKey)
 		if err != nil {
 			return fmt.Errorf("error creating square client: %w", err)
 		}
@@ -246,3 +238,85 @@ func checkCatalogDiscountRemote(resourceName, apiKey string) resource.TestCheckF
 		return compareCatalogDiscountToResource(rs.Primary, res.Object)
 	}
 }
+
+func TestCatalogDiscountCustomizeDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config      map[string]interface{}
+		errContains string
+	}{
+		"fixed percentage": {
+			config: map[string]interface{}{"type": catalogDiscountFixedPercentage, "percentage": "10.5"},
+		},
+		"fixed amount": {
+			config: map[string]interface{}{"type": catalogDiscountFixedAmount, "amount": 100, "currency": "CAD"},
+		},
+		"percentage without percentage": {
+			config:      map[string]interface{}{"type": catalogDiscountVariablePercentage},
+			errContains: "percentage required",
+		},
+		"percentage with amount": {
+			config:      map[string]interface{}{"type": catalogDiscountFixedPercentage, "percentage": "10", "amount": 100},
+			errContains: "amount can't be set",
+		},
+		"percentage with currency": {
+			config:      map[string]interface{}{"type": catalogDiscountFixedPercentage, "percentage": "10", "currency": "USD"},
+			errContains: "currency can't be set",
+		},
+		"amount without amount": {
+			config:      map[string]interface{}{"type": catalogDiscountVariableAmount},
+			errContains: "amount required",
+		},
+		"amount with percentage": {
+			config:      map[string]interface{}{"type": catalogDiscountFixedAmount, "amount": 100, "percentage": "10"},
+			errContains: "percentage can't be set",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			test.config["name"] = "discount"
+
+			_, err := resourceCatalogDiscount().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(test.config), nil)
+
+			switch {
+			case test.errContains == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.errContains != "" && (err == nil || !strings.Contains(err.Error(), test.errContains)):
+				t.Fatalf("expected error containing %q, found %v", test.errContains, err)
+			}
+		})
+	}
+}
+
+func TestCatalogDiscountValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"known type":         {config: map[string]interface{}{"type": catalogDiscountFixedPercentage, "percentage": "5"}, valid: true},
+		"unknown type":       {config: map[string]interface{}{"type": "BOGO", "percentage": "5"}},
+		"invalid percentage": {config: map[string]interface{}{"type": catalogDiscountFixedPercentage, "percentage": "5%"}},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			test.config["name"] = "discount"
+
+			if diags := resourceCatalogDiscount().Validate(terraform.NewResourceConfigRaw(test.config)); diags.HasError() == test.valid {
+				t.Fatalf("expected valid to be %t, found %v", test.valid, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// catalogImageContentTypes are the image formats square accepts.
+var catalogImageContentTypes = map[string]struct{}{
+	"image/jpeg": {},
+	"image/png":  {},
+	"image/gif":  {},
+}
+
+func resourceCatalogImage() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"file": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"content_hash": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"object_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"caption": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		CustomizeDiff: customdiff.All(
+			// Imported images have no object_id in state either, but an image created unattached can only be
+			// attached to an object by uploading it again.
+			customdiff.ForceNewIf("object_id", func(ctx context.Context, d *schema.ResourceDiff, m interface{}) bool {
+				oldHash, _ := d.GetChange("content_hash")
+				oldObjectID, _ := d.GetChange("object_id")
+
+				return d.HasChange("object_id") && (oldObjectID.(string) != "" || oldHash.(string) != "")
+			}),
+			customizeDiffCatalogImage,
+		),
+		CreateContext: resourceCatalogImageCreate,
+		ReadContext:   resourceCatalogRead(catalogImageObjectToResource),
+		UpdateContext: resourceCatalogUpsert(catalogImageResourceToObject, catalogImageObjectToResource),
+		DeleteContext: resourceCatalogDelete(),
+		// Square doesn't keep the uploaded file or the object an image is attached to, so file, content_hash, and
+		// object_id are left unset on import.  The first apply afterwards records the configured file's hash and
+		// object without uploading it again.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCatalogImport(catalogImageObjectToResource),
+		},
+	}
+}
+
+// customizeDiffCatalogImage hashes the image file, so that the image is replaced when the file's contents
+// change.  Moving or renaming a file without changing it only updates file.  An imported image has no hash yet,
+// and takes that of the configured file.
+func customizeDiffCatalogImage(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("file") {
+		return nil
+	}
+
+	_, hash, err := readCatalogImageFile(d.Get("file").(string))
+	if err != nil {
+		return err
+	}
+
+	oldHash := d.Get("content_hash").(string)
+	if hash == oldHash {
+		return nil
+	}
+
+	if err := d.SetNew("content_hash", hash); err != nil {
+		return fmt.Errorf("error setting content hash: %w", err)
+	}
+
+	if d.Id() == "" || oldHash == "" {
+		return nil
+	}
+
+	if err := d.ForceNew("content_hash"); err != nil {
+		return fmt.Errorf("error replacing image: %w", err)
+	}
+
+	return nil
+}
+
+func readCatalogImageFile(file string) ([]byte, string, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading image file: %w", err)
+	}
+
+	hash := sha256.Sum256(b)
+
+	return b, hex.EncodeToString(hash[:]), nil
+}
+
+func resourceCatalogImageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	file := d.Get("file").(string)
+
+	b, hash, err := readCatalogImageFile(file)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	contentType := http.DetectContentType(b)
+	if _, ok := catalogImageContentTypes[contentType]; !ok {
+		return diag.Errorf("image file %s has unsupported content type %s, expected a jpeg, png, or gif", file, contentType)
+	}
+
+	idempotencyKey, err := uuid.NewV4()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating idempotency key: %w", err))
+	}
+
+	object, err := catalogImageResourceToObject(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	image, err := meta.api.CreateCatalogImage(ctx, idempotencyKey.String(), d.Get("object_id").(string), object, filepath.Base(file), contentType, b)
+
+	// Attaching the image changes the object it's attached to.
+	meta.cache.invalidate(d.Get("object_id").(string))
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to create image: %w", err))
+	}
+
+	if err := d.Set("content_hash", hash); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting content hash: %w", err))
+	}
+
+	if err := catalogImageObjectToResource(image, d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func catalogImageResourceToObject(d *schema.ResourceData, meta *providerMeta) (*squareapi.CatalogObject, error) {
+	id := d.Id()
+	if id == "" {
+		id = "#id"
+	}
+
+	return squareapi.NewCatalogObject(&objects.CatalogObject{
+		ID: id,
+		Type: &objects.CatalogImage{
+			Name:    d.Get("name").(string),
+			Caption: d.Get("caption").(string),
+			URL:     d.Get("url").(string),
+		},
+		Version:               d.Get("version").(int),
+		PresentAtAllLocations: true,
+	}), nil
+}
+
+func catalogImageObjectToResource(o *squareapi.CatalogObject, d *schema.ResourceData, meta *providerMeta) error {
+	d.SetId(o.ID)
+
+	image, ok := o.Type.(*objects.CatalogImage)
+	if !ok {
+		return fmt.Errorf("catalog object is not a catalog image")
+	}
+
+	if err := d.Set("name", image.Name); err != nil {
+		return fmt.Errorf("error setting name: %w", err)
+	}
+
+	if err := d.Set("caption", image.Caption); err != nil {
+		return fmt.Errorf("error setting caption: %w", err)
+	}
+
+	if err := d.Set("url", image.URL); err != nil {
+		return fmt.Errorf("error setting url: %w", err)
+	}
+
+	if err := d.Set("version", o.Version); err != nil {
+		return fmt.Errorf("error setting version: %w", err)
+	}
+
+	return nil
+}
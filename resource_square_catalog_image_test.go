@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testPNGHeader is enough of a png file for its content type to be detected.
+const testPNGHeader = "\x89PNG\r\n\x1a\n"
+
+func testImageFile(t *testing.T, name, contents string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, []byte(contents), 0o600); err != nil {
+		t.Fatalf("error writing image file: %v", err)
+	}
+
+	return file
+}
+
+func TestCatalogImage(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	itemResource := resourceCatalogItem()
+	r := resourceCatalogImage()
+
+	itemConfig := map[string]interface{}{
+		"name": "image-item",
+		"variation": []interface{}{
+			map[string]interface{}{"name": "image-variation", "pricing_type": "VARIABLE_PRICING"},
+		},
+	}
+
+	itemState, diags := testApply(t, itemResource, meta, nil, itemConfig)
+	if diags.HasError() {
+		t.Fatalf("error creating item: %v", diags)
+	}
+
+	contents := testPNGHeader + "first"
+	file := testImageFile(t, "image.png", contents)
+	config := map[string]interface{}{
+		"file":      file,
+		"object_id": itemState.ID,
+		"name":      "image",
+		"caption":   "first caption",
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating image: %v", diags)
+	}
+
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(contents)))
+	if state.Attributes["content_hash"] != hash || !strings.Contains(state.Attributes["url"], hash) {
+		t.Fatalf("expected uploaded file to have hash %s, found %v", hash, state.Attributes)
+	}
+
+	// The image is attached to the item, and stays attached when the item is updated.
+	itemState, diags = itemResource.RefreshWithoutUpgrade(context.Background(), itemState, meta)
+	if diags.HasError() {
+		t.Fatalf("error reading item: %v", diags)
+	}
+
+	if itemState.Attributes["image_ids.0"] != state.ID {
+		t.Fatalf("expected image %s to be attached to item, found %v", state.ID, itemState.Attributes)
+	}
+
+	itemConfig["name"] = "image-item-renamed"
+
+	if _, diags := testApply(t, itemResource, meta, itemState, itemConfig); diags.HasError() {
+		t.Fatalf("error updating item: %v", diags)
+	}
+
+	item, err := meta.api.RetrieveCatalogObject(context.Background(), itemState.ID)
+	if err != nil {
+		t.Fatalf("error retrieving item: %v", err)
+	}
+
+	if imageIDs, _ := item.ExtraField(item.ID, "image_ids").([]interface{}); len(imageIDs) != 1 || imageIDs[0] != state.ID {
+		t.Fatalf("expected image to stay attached to item, found %v", item.Extra[item.ID])
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning image: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+
+	// Changing the caption updates the image in place.
+	config["caption"] = "second caption"
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning image: %v", err)
+	}
+
+	if diff.RequiresNew() {
+		t.Fatalf("expected caption to be updated in place, found %v", diff)
+	}
+
+	state, diags = r.Apply(context.Background(), state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("error updating image: %v", diags)
+	}
+
+	image, err := meta.api.RetrieveCatalogObject(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving image: %v", err)
+	}
+
+	if caption := image.Type.(*objects.CatalogImage).Caption; caption != "second caption" {
+		t.Fatalf("expected caption to be updated, found %s", caption)
+	}
+
+	// Moving the file without changing it updates the image in place.
+	moved := testImageFile(t, "moved.png", contents)
+	config["file"] = moved
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning image: %v", err)
+	}
+
+	if diff.RequiresNew() {
+		t.Fatalf("expected moving an identical file to update the image in place, found %v", diff)
+	}
+
+	state, diags = r.Apply(context.Background(), state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("error updating image: %v", diags)
+	}
+
+	if state.Attributes["file"] != moved || state.Attributes["content_hash"] != hash {
+		t.Fatalf("expected the moved file to be recorded with the same hash, found %v", state.Attributes)
+	}
+
+	// Changing the file's contents replaces the image.
+	if err := os.WriteFile(moved, []byte(testPNGHeader+"second"), 0o600); err != nil {
+		t.Fatalf("error writing image file: %v", err)
+	}
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning image: %v", err)
+	}
+
+	if !diff.RequiresNew() {
+		t.Fatalf("expected changing the file to replace the image, found %v", diff)
+	}
+}
+
+func TestCatalogImageUnsupportedFile(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+
+	_, diags := testApply(t, resourceCatalogImage(), meta, nil, map[string]interface{}{
+		"file": testImageFile(t, "image.txt", "not an image"),
+	})
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "unsupported content type") {
+		t.Fatalf("expected unsupported content type error, found %v", diags)
+	}
+}
+
+func TestCatalogImageImport(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogImage()
+
+	itemState, diags := testApply(t, resourceCatalogItem(), meta, nil, map[string]interface{}{
+		"name": "imported-image-item",
+		"variation": []interface{}{
+			map[string]interface{}{"name": "imported-image-variation", "pricing_type": "VARIABLE_PRICING"},
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating item: %v", diags)
+	}
+
+	contents := testPNGHeader + "imported"
+	config := map[string]interface{}{
+		"file":      testImageFile(t, "image.png", contents),
+		"object_id": itemState.ID,
+		"name":      "imported-image",
+		"caption":   "imported caption",
+	}
+
+	created, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating image: %v", diags)
+	}
+
+	imported, err := r.Importer.StateContext(context.Background(), r.Data(&terraform.InstanceState{ID: created.ID}), meta)
+	if err != nil {
+		t.Fatalf("error importing image: %v", err)
+	}
+
+	state := imported[0].State()
+	if state.Attributes["name"] != "imported-image" || state.Attributes["url"] != created.Attributes["url"] {
+		t.Fatalf("unexpected imported image %v", state.Attributes)
+	}
+
+	if state.Attributes["file"] != "" || state.Attributes["content_hash"] != "" || state.Attributes["object_id"] != "" {
+		t.Fatalf("expected file, content_hash, and object_id to be left unset on import, found %v", state.Attributes)
+	}
+
+	// The configured file and object are adopted, rather than replacing the imported image.
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning image: %v", err)
+	}
+
+	if diff.RequiresNew() {
+		t.Fatalf("expected imported image not to be replaced, found %v", diff)
+	}
+
+	state, diags = r.Apply(context.Background(), state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("error applying imported image: %v", diags)
+	}
+
+	if state.ID != created.ID || state.Attributes["content_hash"] != created.Attributes["content_hash"] || state.Attributes["object_id"] != itemState.ID {
+		t.Fatalf("expected imported image to record the file's hash and object, found %v", state.Attributes)
+	}
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning image: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after adopting imported image: %v", diff)
+	}
+
+	// Attaching an image that was created unattached still replaces it.
+	unattached, diags := testApply(t, r, meta, nil, map[string]interface{}{
+		"file": testImageFile(t, "unattached.png", testPNGHeader+"unattached"),
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating image: %v", diags)
+	}
+
+	diff, err = r.Diff(context.Background(), unattached, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"file":      unattached.Attributes["file"],
+		"object_id": itemState.ID,
+	}), meta)
+	if err != nil {
+		t.Fatalf("error planning image: %v", err)
+	}
+
+	if !diff.RequiresNew() {
+		t.Fatalf("expected attaching an unattached image to replace it, found %v", diff)
+	}
+}
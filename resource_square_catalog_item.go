@@ -1,15 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"sort"
 
 	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
 	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var variationSchema = &schema.Resource{
-	Schema: map[string]*schema.Schema{
+	Schema: withLocationPresence(map[string]*schema.Schema{
 		"id": &schema.Schema{
 			Type:     schema.TypeString,
 			Computed: true,
@@ -25,47 +32,349 @@ var variationSchema = &schema.Resource{
 		"pricing_type": &schema.Schema{
 			Type:     schema.TypeString,
 			Required: true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+				string(objects.CatalogPricingTypeFixed),
+				string(objects.CatalogPricingTypeVariable),
+			}, false)),
 		},
 		"amount": &schema.Schema{
 			Type:     schema.TypeInt,
 			Optional: true,
 		},
+		"currency": &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validateCurrency,
+		},
+		"sku": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"upc": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"ordinal": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"track_inventory": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"inventory_alert_type": &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{inventoryAlertTypeNone, inventoryAlertTypeLowQuantity}, false)),
+		},
+		"inventory_alert_threshold": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"service_duration": &schema.Schema{
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+		},
+		"available_for_booking": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"sellable": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"stockable": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"measurement_unit_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"location_override": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     locationOverrideSchema,
+		},
+		"item_option_value": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     itemOptionValueForVariationSchema,
+		},
+	}),
+}
+
+var itemOptionValueForVariationSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"item_option_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"item_option_value_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	},
+}
+
+var locationOverrideSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"location_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"amount": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"currency": &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validateCurrency,
+		},
+		"track_inventory": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"inventory_alert_type": &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{inventoryAlertTypeNone, inventoryAlertTypeLowQuantity}, false)),
+		},
+		"inventory_alert_threshold": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+	},
+}
+
+const (
+	inventoryAlertTypeNone        = "NONE"
+	inventoryAlertTypeLowQuantity = "LOW_QUANTITY"
+)
+
+// Item visibility isn't supported by square-go, so it's sent as an extra field.
+const (
+	itemVisibilityPrivate = "PRIVATE"
+	itemVisibilityPublic  = "PUBLIC"
+)
+
+var itemProductTypes = []string{
+	string(objects.CatalogItemProductTypeRegular),
+	string(objects.CatalogItemProductTypeGiftCard),
+	string(objects.CatalogItemProductTypeAppointmentsService),
+	string(objects.CatalogItemProductTypeRetailItem),
+	string(objects.CatalogItemProductTypeRestaurantItem),
+}
+
+var labelColorRegexp = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+var modifierListInfoSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"modifier_list_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"min_selected_modifiers": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"max_selected_modifiers": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"enabled": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
 	},
 }
 
 func resourceCatalogItem() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withLocationPresence(map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"abbreviation": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"label_color": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(labelColorRegexp, "must be a 6 digit hex color")),
+			},
+			"product_type": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          string(objects.CatalogItemProductTypeRegular),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(itemProductTypes, false)),
+			},
+			"skip_modifier_screen": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"available_online": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"available_for_pickup": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"visibility": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{itemVisibilityPrivate, itemVisibilityPublic}, false)),
+			},
+			"category_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tax_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"modifier_list_info": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     modifierListInfoSchema,
+			},
+			"item_option_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"variation": &schema.Schema{
 				Type:     schema.TypeSet,
 				Required: true,
 				Elem:     variationSchema,
 			},
+			"image_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"version": &schema.Schema{
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
-		},
-		CreateContext: resourceCatalogUpsert(catalogItemResourceToObject, catalogItemObjectToResource),
+		}),
+		CustomizeDiff: customdiff.All(customizeDiffCatalogItem, customizeDiffVariationPricing),
+		CreateContext: resourceCatalogItemUpsert,
 		ReadContext:   resourceCatalogRead(catalogItemObjectToResource),
-		UpdateContext: resourceCatalogUpsert(catalogItemResourceToObject, catalogItemObjectToResource),
+		UpdateContext: resourceCatalogItemUpsert,
 		DeleteContext: resourceCatalogDelete(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCatalogImport(catalogItemObjectToResource),
+		},
+	}
+}
+
+// resourceCatalogItemUpsert checks that the option values picked by each variation belong to their options
+// before upserting the item, as square's own error doesn't say which variation is wrong.
+func resourceCatalogItemUpsert(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	if err := checkItemOptionValues(ctx, d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceCatalogUpsert(catalogItemResourceToObject, catalogItemObjectToResource)(ctx, d, m)
+}
+
+// checkItemOptionValues checks every item_option_value_id picked by a variation against the values of its
+// item option.
+func checkItemOptionValues(ctx context.Context, d *schema.ResourceData, meta *providerMeta) error {
+	optionValues := map[string]map[string]struct{}{}
+
+	for _, v := range d.Get("variation").(*schema.Set).List() {
+		mv := v.(map[string]interface{})
+
+		for _, ov := range mv["item_option_value"].([]interface{}) {
+			mov := ov.(map[string]interface{})
+			optionID, valueID := mov["item_option_id"].(string), mov["item_option_value_id"].(string)
+
+			values, ok := optionValues[optionID]
+			if !ok {
+				var err error
+
+				values, err = retrieveItemOptionValueIDs(ctx, meta, optionID)
+				if err != nil {
+					return err
+				}
+
+				optionValues[optionID] = values
+			}
+
+			if _, ok := values[valueID]; !ok {
+				return fmt.Errorf("error in variation %q: item option value %s is not one of the values of item option %s", mv["name"], valueID, optionID)
+			}
+		}
 	}
+
+	return nil
+}
+
+func retrieveItemOptionValueIDs(ctx context.Context, meta *providerMeta, optionID string) (map[string]struct{}, error) {
+	object, err := meta.api.RetrieveCatalogObject(ctx, optionID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("item option %s not found", optionID)
+		}
+
+		return nil, fmt.Errorf("error making network call to retrieve item option: %w", err)
+	}
+
+	option, ok := object.Type.(*objects.CatalogItemOption)
+	if !ok || object.IsDeleted {
+		return nil, fmt.Errorf("catalog object %s is not an item option", optionID)
+	}
+
+	values := make(map[string]struct{}, len(option.Values))
+	for _, v := range option.Values {
+		values[v.ID] = struct{}{}
+	}
+
+	return values, nil
 }
 
-func catalogItemResourceToObject(d *schema.ResourceData) (*objects.CatalogObject, error) {
+func catalogItemResourceToObject(d *schema.ResourceData, meta *providerMeta) (*squareapi.CatalogObject, error) {
 	id := d.Id()
 	if id == "" {
 		id = "#id"
 	}
 
+	dItemOptionIDs := d.Get("item_option_ids").([]interface{})
+	itemOptions := make([]*objects.CatalogItemOptionForItem, len(dItemOptionIDs))
+	itemOptionIDs := make([]string, len(dItemOptionIDs))
+
+	for i, o := range dItemOptionIDs {
+		itemOptionIDs[i] = o.(string)
+		itemOptions[i] = &objects.CatalogItemOptionForItem{ItemOptionID: o.(string)}
+	}
+
 	dVariations := d.Get("variation").(*schema.Set)
 	variations := make([]*objects.CatalogObject, dVariations.Len())
 
+	// sellable and stockable aren't supported by square-go, so they're sent as extra fields.
+	extra := map[string]map[string]interface{}{}
+
 	for i, v := range dVariations.List() {
 		mv := v.(map[string]interface{})
 
@@ -89,34 +398,119 @@ func catalogItemResourceToObject(d *schema.ResourceData) (*objects.CatalogObject
 			pricingType = objects.CatalogPricingTypeFixed
 			money = &objects.Money{
 				Amount:   mv["amount"].(int),
-				Currency: "USD",
+				Currency: meta.currency(mv["currency"].(string)),
 			}
 		case "VARIABLE_PRICING":
 			pricingType = objects.CatalogPricingTypeVariable
+		default:
+			return nil, fmt.Errorf("unknown pricing type %s in variation %q", mv["pricing_type"], mv["name"])
+		}
+
+		locationOverrides, err := locationOverridesResourceToObject(mv["location_override"].([]interface{}), meta)
+		if err != nil {
+			return nil, fmt.Errorf("error in variation %q: %w", mv["name"], err)
+		}
+
+		alertType, err := inventoryAlertTypeResourceToObject(mv["inventory_alert_type"].(string), mv["inventory_alert_threshold"].(int))
+		if err != nil {
+			return nil, fmt.Errorf("error in variation %q: %w", mv["name"], err)
+		}
+
+		itemOptionValues, err := itemOptionValuesResourceToObject(mv["item_option_value"].([]interface{}), itemOptionIDs)
+		if err != nil {
+			return nil, fmt.Errorf("error in variation %q: %w", mv["name"], err)
 		}
 
 		variations[i] = &objects.CatalogObject{
 			ID: vid,
 			Type: &objects.CatalogItemVariation{
-				ItemID:      id,
-				Name:        mv["name"].(string),
-				PricingType: pricingType,
-				PriceMoney:  money,
+				ItemID:              id,
+				Name:                mv["name"].(string),
+				SKU:                 mv["sku"].(string),
+				UPC:                 mv["upc"].(string),
+				Ordinal:             mv["ordinal"].(int),
+				PricingType:         pricingType,
+				PriceMoney:          money,
+				LocationOverrides:   locationOverrides,
+				TrackInventory:      mv["track_inventory"].(bool),
+				InventoryAlertType:  alertType,
+				ServiceDuration:     mv["service_duration"].(int),
+				AvailableForBooking: mv["available_for_booking"].(bool),
+				MeasurementUnitID:   mv["measurement_unit_id"].(string),
+				ItemOptionValues:    itemOptionValues,
 			},
 		}
+
+		extra[vid] = map[string]interface{}{
+			"sellable":  mv["sellable"].(bool),
+			"stockable": mv["stockable"].(bool),
+		}
+
+		locationPresenceFromResource(func(k string) interface{} { return mv[k] }).apply(variations[i])
+	}
+
+	dTaxIDs := d.Get("tax_ids").(*schema.Set)
+	taxIDs := make([]string, dTaxIDs.Len())
+
+	for i, t := range dTaxIDs.List() {
+		taxIDs[i] = t.(string)
+	}
+
+	dModifierListInfo := d.Get("modifier_list_info").(*schema.Set)
+	modifierListInfo := make([]*objects.CatalogItemModifierListInfo, dModifierListInfo.Len())
+
+	for i, m := range dModifierListInfo.List() {
+		mm := m.(map[string]interface{})
+		enabled := mm["enabled"].(bool)
+
+		modifierListInfo[i] = &objects.CatalogItemModifierListInfo{
+			ModifierListID:       mm["modifier_list_id"].(string),
+			MinSelectedModifiers: mm["min_selected_modifiers"].(int),
+			MaxSelectedModifiers: mm["max_selected_modifiers"].(int),
+			Enabled:              &enabled,
+		}
 	}
 
-	return &objects.CatalogObject{
+	item := &objects.CatalogObject{
 		ID: id,
 		Type: &objects.CatalogItem{
-			Name:       d.Get("name").(string),
-			Variations: variations,
+			Name:               d.Get("name").(string),
+			Description:        d.Get("description").(string),
+			Abbreviation:       d.Get("abbreviation").(string),
+			LabelColor:         d.Get("label_color").(string),
+			ProductType:        objects.CatalogItemProductType(d.Get("product_type").(string)),
+			SkipModifierScreen: d.Get("skip_modifier_screen").(bool),
+			AvailableOnline:    d.Get("available_online").(bool),
+			AvailableForPickup: d.Get("available_for_pickup").(bool),
+			CategoryID:         d.Get("category_id").(string),
+			TaxIDs:             taxIDs,
+			ModifierListInfo:   modifierListInfo,
+			Variations:         variations,
+			ItemOptions:        itemOptions,
 		},
 		Version: d.Get("version").(int),
-	}, nil
+	}
+
+	locationPresenceFromResource(d.Get).apply(item)
+
+	// Images are attached by square_catalog_image, and are sent back so that updating the item keeps them.
+	extra[id] = map[string]interface{}{}
+
+	if visibility := d.Get("visibility").(string); visibility != "" {
+		extra[id]["visibility"] = visibility
+	}
+
+	if imageIDs := d.Get("image_ids").([]interface{}); len(imageIDs) > 0 {
+		extra[id]["image_ids"] = imageIDs
+	}
+
+	o := squareapi.NewCatalogObject(item)
+	o.Extra = extra
+
+	return o, nil
 }
 
-func catalogItemObjectToResource(o *objects.CatalogObject, d *schema.ResourceData) error {
+func catalogItemObjectToResource(o *squareapi.CatalogObject, d *schema.ResourceData, meta *providerMeta) error {
 	d.SetId(o.ID)
 
 	item, ok := o.Type.(*objects.CatalogItem)
@@ -128,10 +522,105 @@ func catalogItemObjectToResource(o *objects.CatalogObject, d *schema.ResourceDat
 		return fmt.Errorf("error setting name: %w", err)
 	}
 
+	if err := d.Set("description", item.Description); err != nil {
+		return fmt.Errorf("error setting description: %w", err)
+	}
+
+	if err := d.Set("abbreviation", item.Abbreviation); err != nil {
+		return fmt.Errorf("error setting abbreviation: %w", err)
+	}
+
+	if err := d.Set("label_color", item.LabelColor); err != nil {
+		return fmt.Errorf("error setting label color: %w", err)
+	}
+
+	productType := string(item.ProductType)
+	if productType == "" {
+		productType = string(objects.CatalogItemProductTypeRegular)
+	}
+
+	if err := d.Set("product_type", productType); err != nil {
+		return fmt.Errorf("error setting product type: %w", err)
+	}
+
+	if err := d.Set("skip_modifier_screen", item.SkipModifierScreen); err != nil {
+		return fmt.Errorf("error setting skip modifier screen: %w", err)
+	}
+
+	if err := d.Set("available_online", item.AvailableOnline); err != nil {
+		return fmt.Errorf("error setting available online: %w", err)
+	}
+
+	if err := d.Set("available_for_pickup", item.AvailableForPickup); err != nil {
+		return fmt.Errorf("error setting available for pickup: %w", err)
+	}
+
+	visibility, _ := o.ExtraField(o.ID, "visibility").(string)
+	if err := d.Set("visibility", visibility); err != nil {
+		return fmt.Errorf("error setting visibility: %w", err)
+	}
+
+	imageIDs, _ := o.ExtraField(o.ID, "image_ids").([]interface{})
+	if err := d.Set("image_ids", imageIDs); err != nil {
+		return fmt.Errorf("error setting image ids: %w", err)
+	}
+
+	if err := d.Set("category_id", item.CategoryID); err != nil {
+		return fmt.Errorf("error setting category id: %w", err)
+	}
+
+	taxIDs := make([]interface{}, len(item.TaxIDs))
+	for i, t := range item.TaxIDs {
+		taxIDs[i] = t
+	}
+
+	if err := d.Set("tax_ids", schema.NewSet(schema.HashString, taxIDs)); err != nil {
+		return fmt.Errorf("error setting tax ids: %w", err)
+	}
+
+	modifierListInfo := make([]interface{}, len(item.ModifierListInfo))
+
+	for i, m := range item.ModifierListInfo {
+		enabled := true
+		if m.Enabled != nil {
+			enabled = *m.Enabled
+		}
+
+		modifierListInfo[i] = map[string]interface{}{
+			"modifier_list_id":       m.ModifierListID,
+			"min_selected_modifiers": m.MinSelectedModifiers,
+			"max_selected_modifiers": m.MaxSelectedModifiers,
+			"enabled":                enabled,
+		}
+	}
+
+	if err := d.Set("modifier_list_info", schema.NewSet(schema.HashResource(modifierListInfoSchema), modifierListInfo)); err != nil {
+		return fmt.Errorf("error setting modifier list info: %w", err)
+	}
+
+	itemOptionIDs := make([]interface{}, len(item.ItemOptions))
+	for i, o := range item.ItemOptions {
+		itemOptionIDs[i] = o.ItemOptionID
+	}
+
+	if err := d.Set("item_option_ids", itemOptionIDs); err != nil {
+		return fmt.Errorf("error setting item option ids: %w", err)
+	}
+
 	if len(item.Variations) < 1 {
 		return fmt.Errorf("expected at least one item variation")
 	}
 
+	// Configured variations are matched by id, or by name for variations that are being created.
+	configuredVariations := map[string]map[string]interface{}{}
+	configuredVariationsByName := map[string]map[string]interface{}{}
+
+	for _, v := range d.Get("variation").(*schema.Set).List() {
+		mv := v.(map[string]interface{})
+		configuredVariations[mv["id"].(string)] = mv
+		configuredVariationsByName[mv["name"].(string)] = mv
+	}
+
 	variations := make([]interface{}, len(item.Variations))
 
 	for i, vo := range item.Variations {
@@ -140,26 +629,313 @@ func catalogItemObjectToResource(o *objects.CatalogObject, d *schema.ResourceDat
 			return fmt.Errorf("catalog object is not a catalog item variation")
 		}
 
-		var amount int
+		var (
+			amount             int
+			currency           string
+			configuredCurrency string
+			configuredOverride []interface{}
+			configuredOrdinal  = -1
+		)
+
+		configured, ok := configuredVariations[vo.ID]
+		if !ok {
+			configured, ok = configuredVariationsByName[v.Name]
+		}
+
+		if ok {
+			configuredCurrency = configured["currency"].(string)
+			configuredOverride = configured["location_override"].([]interface{})
+			configuredOrdinal = configured["ordinal"].(int)
+		}
+
 		if v.PricingType == objects.CatalogPricingTypeFixed {
 			amount = v.PriceMoney.Amount
+			currency = meta.stateCurrency(v.PriceMoney.Currency, configuredCurrency)
+		}
+
+		// Square assigns ordinals to variations that don't have one, which isn't drift when none was configured.
+		ordinal := v.Ordinal
+		if configuredOrdinal == 0 {
+			ordinal = 0
+		}
+
+		alertType, threshold := inventoryAlertTypeObjectToResource(v.InventoryAlertType)
+
+		variation := map[string]interface{}{
+			"id":                        vo.ID,
+			"name":                      v.Name,
+			"pricing_type":              string(v.PricingType),
+			"amount":                    amount,
+			"currency":                  currency,
+			"sku":                       v.SKU,
+			"upc":                       v.UPC,
+			"ordinal":                   ordinal,
+			"track_inventory":           v.TrackInventory,
+			"inventory_alert_type":      alertType,
+			"inventory_alert_threshold": threshold,
+			"service_duration":          v.ServiceDuration,
+			"available_for_booking":     v.AvailableForBooking,
+			"sellable":                  o.ExtraBool(vo.ID, "sellable", true),
+			"stockable":                 o.ExtraBool(vo.ID, "stockable", true),
+			"measurement_unit_id":       v.MeasurementUnitID,
+			"location_override":         locationOverridesObjectToResource(v.LocationOverrides, configuredOverride, meta),
+			"item_option_value":         itemOptionValuesObjectToResource(v.ItemOptionValues),
 		}
 
-		variations[i] = map[string]interface{}{
-			"id":           vo.ID,
-			"name":         v.Name,
-			"pricing_type": string(v.PricingType),
-			"amount":       amount,
+		for k, p := range locationPresenceToMap(vo) {
+			variation[k] = p
 		}
+
+		variations[i] = variation
 	}
 
 	if err := d.Set("variation", schema.NewSet(schema.HashResource(variationSchema), variations)); err != nil {
 		return fmt.Errorf("error setting variations: %w", err)
 	}
 
+	if err := setLocationPresence(o.CatalogObject, d); err != nil {
+		return err
+	}
+
 	if err := d.Set("version", o.Version); err != nil {
 		return fmt.Errorf("error setting version: %w", err)
 	}
 
 	return nil
 }
+
+// customizeDiffCatalogItem checks that the location presence of the item and each of its variations is
+// coherent.
+func customizeDiffCatalogItem(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("present_at_location_ids") || !d.NewValueKnown("absent_at_location_ids") {
+		return nil
+	}
+
+	item := locationPresenceFromResource(d.Get)
+	if err := item.validate(); err != nil {
+		return err
+	}
+
+	if !d.NewValueKnown("variation") {
+		return nil
+	}
+
+	for _, v := range d.Get("variation").(*schema.Set).List() {
+		mv := v.(map[string]interface{})
+		variation := locationPresenceFromResource(func(k string) interface{} { return mv[k] })
+
+		if err := variation.validate(); err != nil {
+			return fmt.Errorf("variation %q: %w", mv["name"], err)
+		}
+
+		if err := variation.validateWithin(item); err != nil {
+			return fmt.Errorf("variation %q: %w", mv["name"], err)
+		}
+	}
+
+	return nil
+}
+
+// customizeDiffVariationPricing checks that variations with variable pricing don't set a price.
+func customizeDiffVariationPricing(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("variation") {
+		return nil
+	}
+
+	for _, v := range d.Get("variation").(*schema.Set).List() {
+		mv := v.(map[string]interface{})
+		if mv["pricing_type"].(string) != string(objects.CatalogPricingTypeVariable) {
+			continue
+		}
+
+		if mv["amount"].(int) != 0 {
+			return fmt.Errorf("variation %q: amount can't be set with a pricing_type of %s", mv["name"], objects.CatalogPricingTypeVariable)
+		}
+
+		if mv["currency"].(string) != "" {
+			return fmt.Errorf("variation %q: currency can't be set with a pricing_type of %s", mv["name"], objects.CatalogPricingTypeVariable)
+		}
+	}
+
+	return nil
+}
+
+// itemOptionValuesResourceToObject converts the option values picked by a variation, checking that they
+// pick exactly one value for each of the item's options.
+func itemOptionValuesResourceToObject(dValues []interface{}, itemOptionIDs []string) ([]*objects.CatalogItemOptionValueForItemVariation, error) {
+	values := make([]*objects.CatalogItemOptionValueForItemVariation, len(dValues))
+	seen := map[string]struct{}{}
+
+	for i, v := range dValues {
+		mv := v.(map[string]interface{})
+
+		optionID := mv["item_option_id"].(string)
+		if !containsString(itemOptionIDs, optionID) {
+			return nil, fmt.Errorf("item option %s is not one of the item's item_option_ids", optionID)
+		}
+
+		if _, ok := seen[optionID]; ok {
+			return nil, fmt.Errorf("more than one value for item option %s", optionID)
+		}
+
+		seen[optionID] = struct{}{}
+
+		values[i] = &objects.CatalogItemOptionValueForItemVariation{
+			ItemOptionID:      optionID,
+			ItemOptionValueID: mv["item_option_value_id"].(string),
+		}
+	}
+
+	for _, optionID := range itemOptionIDs {
+		if _, ok := seen[optionID]; !ok {
+			return nil, fmt.Errorf("missing a value for item option %s", optionID)
+		}
+	}
+
+	return values, nil
+}
+
+func itemOptionValuesObjectToResource(values []*objects.CatalogItemOptionValueForItemVariation) []interface{} {
+	dValues := make([]interface{}, len(values))
+
+	for i, v := range values {
+		dValues[i] = map[string]interface{}{
+			"item_option_id":       v.ItemOptionID,
+			"item_option_value_id": v.ItemOptionValueID,
+		}
+	}
+
+	return dValues
+}
+
+func locationOverridesResourceToObject(dOverrides []interface{}, meta *providerMeta) ([]*objects.ItemVariationLocationOverrides, error) {
+	overrides := make([]*objects.ItemVariationLocationOverrides, len(dOverrides))
+	seen := map[string]struct{}{}
+
+	for i, o := range dOverrides {
+		mo := o.(map[string]interface{})
+
+		locationID := mo["location_id"].(string)
+		if _, ok := seen[locationID]; ok {
+			return nil, fmt.Errorf("more than one location_override for location %s", locationID)
+		}
+
+		seen[locationID] = struct{}{}
+
+		alertType, err := inventoryAlertTypeResourceToObject(mo["inventory_alert_type"].(string), mo["inventory_alert_threshold"].(int))
+		if err != nil {
+			return nil, fmt.Errorf("error in location_override for location %s: %w", locationID, err)
+		}
+
+		overrides[i] = &objects.ItemVariationLocationOverrides{
+			LocationID:         locationID,
+			TrackInventory:     mo["track_inventory"].(bool),
+			InventoryAlertType: alertType,
+		}
+
+		if amount := mo["amount"].(int); amount != 0 {
+			overrides[i].PricingType = objects.CatalogPricingTypeFixed
+			overrides[i].PriceMoney = &objects.Money{
+				Amount:   amount,
+				Currency: meta.currency(mo["currency"].(string)),
+			}
+		}
+	}
+
+	return overrides, nil
+}
+
+// locationOverridesObjectToResource converts location overrides into their terraform representation.
+// Overrides are kept in the order they were configured in, followed by any others sorted by location id, so
+// that the order square returns them in doesn't cause a diff.
+func locationOverridesObjectToResource(overrides []*objects.ItemVariationLocationOverrides, configured []interface{}, meta *providerMeta) []interface{} {
+	configuredOrder := map[string]int{}
+	configuredCurrencies := map[string]string{}
+
+	for i, c := range configured {
+		mc, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		locationID := mc["location_id"].(string)
+		configuredOrder[locationID] = i
+		configuredCurrencies[locationID] = mc["currency"].(string)
+	}
+
+	sorted := make([]*objects.ItemVariationLocationOverrides, len(overrides))
+	copy(sorted, overrides)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		oi, iConfigured := configuredOrder[sorted[i].LocationID]
+		oj, jConfigured := configuredOrder[sorted[j].LocationID]
+
+		switch {
+		case iConfigured && jConfigured:
+			return oi < oj
+		case iConfigured != jConfigured:
+			return iConfigured
+		}
+
+		return sorted[i].LocationID < sorted[j].LocationID
+	})
+
+	dOverrides := make([]interface{}, len(sorted))
+
+	for i, o := range sorted {
+		var (
+			amount   int
+			currency string
+		)
+
+		if o.PriceMoney != nil {
+			amount = o.PriceMoney.Amount
+			currency = meta.stateCurrency(o.PriceMoney.Currency, configuredCurrencies[o.LocationID])
+		}
+
+		alertType, threshold := inventoryAlertTypeObjectToResource(o.InventoryAlertType)
+
+		dOverrides[i] = map[string]interface{}{
+			"location_id":               o.LocationID,
+			"amount":                    amount,
+			"currency":                  currency,
+			"track_inventory":           o.TrackInventory,
+			"inventory_alert_type":      alertType,
+			"inventory_alert_threshold": threshold,
+		}
+	}
+
+	return dOverrides
+}
+
+func inventoryAlertTypeResourceToObject(alertType string, threshold int) (objects.InventoryAlertType, error) {
+	switch alertType {
+	case "":
+		if threshold != 0 {
+			return nil, fmt.Errorf("inventory_alert_threshold requires an inventory_alert_type of %s", inventoryAlertTypeLowQuantity)
+		}
+
+		return nil, nil
+	case inventoryAlertTypeNone:
+		if threshold != 0 {
+			return nil, fmt.Errorf("inventory_alert_threshold requires an inventory_alert_type of %s", inventoryAlertTypeLowQuantity)
+		}
+
+		return &objects.InventoryAlertTypeNone{}, nil
+	case inventoryAlertTypeLowQuantity:
+		return &objects.InventoryAlertTypeLowQuantity{Threshold: threshold}, nil
+	}
+
+	return nil, fmt.Errorf("unknown inventory alert type %s", alertType)
+}
+
+func inventoryAlertTypeObjectToResource(alertType objects.InventoryAlertType) (string, int) {
+	switch t := alertType.(type) {
+	case *objects.InventoryAlertTypeNone:
+		return inventoryAlertTypeNone, 0
+	case *objects.InventoryAlertTypeLowQuantity:
+		return inventoryAlertTypeLowQuantity, t.Threshold
+	}
+
+	return "", 0
+}
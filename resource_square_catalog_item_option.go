@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var itemOptionValueSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"id": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"color": &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(labelColorRegexp, "must be a 6 digit hex color")),
+		},
+		"ordinal": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+	},
+}
+
+func resourceCatalogItemOption() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"display_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"show_colors": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"value": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     itemOptionValueSchema,
+			},
+			"value_ids": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		CustomizeDiff: customdiff.ComputedIf("value_ids", itemOptionValuesChanged),
+		CreateContext: resourceCatalogUpsert(catalogItemOptionResourceToObject, catalogItemOptionObjectToResource),
+		ReadContext:   resourceCatalogRead(catalogItemOptionObjectToResource),
+		UpdateContext: resourceCatalogUpsert(catalogItemOptionResourceToObject, catalogItemOptionObjectToResource),
+		DeleteContext: resourceCatalogDelete(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCatalogImport(catalogItemOptionObjectToResource),
+		},
+	}
+}
+
+// itemOptionValuesChanged reports whether any values are being added or removed.  Values are compared by
+// hash, as the configured values don't have ids yet.
+func itemOptionValuesChanged(ctx context.Context, d *schema.ResourceDiff, m interface{}) bool {
+	o, n := d.GetChange("value")
+	oldValues, newValues := o.(*schema.Set), n.(*schema.Set)
+
+	return oldValues.Difference(newValues).Len() > 0 || newValues.Difference(oldValues).Len() > 0
+}
+
+func catalogItemOptionResourceToObject(d *schema.ResourceData, meta *providerMeta) (*squareapi.CatalogObject, error) {
+	id := d.Id()
+	if id == "" {
+		id = "#id"
+	}
+
+	dValues := d.Get("value").(*schema.Set)
+	values := make([]*objects.CatalogObject, dValues.Len())
+	names := map[string]struct{}{}
+
+	for i, v := range dValues.List() {
+		mv := v.(map[string]interface{})
+
+		name := mv["name"].(string)
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("more than one item option value named %q", name)
+		}
+
+		names[name] = struct{}{}
+
+		vid := mv["id"].(string)
+		if vid == "" {
+			uid, err := uuid.NewV4()
+			if err != nil {
+				return nil, fmt.Errorf("error generating uuid for new item option value: %w", err)
+			}
+
+			vid = "#" + uid.String()
+		}
+
+		values[i] = &objects.CatalogObject{
+			ID: vid,
+			Type: &objects.CatalogItemOptionValue{
+				ItemOptionID: id,
+				Name:         name,
+				Description:  mv["description"].(string),
+				Color:        mv["color"].(string),
+				Ordinal:      mv["ordinal"].(int),
+			},
+			PresentAtAllLocations: true,
+		}
+	}
+
+	return squareapi.NewCatalogObject(&objects.CatalogObject{
+		ID: id,
+		Type: &objects.CatalogItemOption{
+			Name:        d.Get("name").(string),
+			DisplayName: d.Get("display_name").(string),
+			Description: d.Get("description").(string),
+			ShowColors:  d.Get("show_colors").(bool),
+			Values:      values,
+		},
+		Version:               d.Get("version").(int),
+		PresentAtAllLocations: true,
+	}), nil
+}
+
+func catalogItemOptionObjectToResource(o *squareapi.CatalogObject, d *schema.ResourceData, meta *providerMeta) error {
+	d.SetId(o.ID)
+
+	option, ok := o.Type.(*objects.CatalogItemOption)
+	if !ok {
+		return fmt.Errorf("catalog object is not a catalog item option")
+	}
+
+	if err := d.Set("name", option.Name); err != nil {
+		return fmt.Errorf("error setting name: %w", err)
+	}
+
+	if err := d.Set("display_name", option.DisplayName); err != nil {
+		return fmt.Errorf("error setting display name: %w", err)
+	}
+
+	if err := d.Set("description", option.Description); err != nil {
+		return fmt.Errorf("error setting description: %w", err)
+	}
+
+	if err := d.Set("show_colors", option.ShowColors); err != nil {
+		return fmt.Errorf("error setting show colors: %w", err)
+	}
+
+	values := make([]interface{}, len(option.Values))
+	valueIDs := make(map[string]interface{}, len(option.Values))
+
+	for i, vo := range option.Values {
+		v, ok := vo.Type.(*objects.CatalogItemOptionValue)
+		if !ok {
+			return fmt.Errorf("catalog object is not a catalog item option value")
+		}
+
+		values[i] = map[string]interface{}{
+			"id":          vo.ID,
+			"name":        v.Name,
+			"description": v.Description,
+			"color":       v.Color,
+			"ordinal":     v.Ordinal,
+		}
+		valueIDs[v.Name] = vo.ID
+	}
+
+	if err := d.Set("value", schema.NewSet(schema.HashResource(itemOptionValueSchema), values)); err != nil {
+		return fmt.Errorf("error setting values: %w", err)
+	}
+
+	if err := d.Set("value_ids", valueIDs); err != nil {
+		return fmt.Errorf("error setting value ids: %w", err)
+	}
+
+	if err := d.Set("version", o.Version); err != nil {
+		return fmt.Errorf("error setting version: %w", err)
+	}
+
+	return nil
+}
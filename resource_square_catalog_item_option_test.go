@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCatalogItemOption(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogItemOption()
+
+	config := map[string]interface{}{
+		"name":         "option-size",
+		"display_name": "Size",
+		"value": []interface{}{
+			map[string]interface{}{"name": "S", "ordinal": 1},
+			map[string]interface{}{"name": "M", "ordinal": 2, "color": "ff0000"},
+		},
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating item option: %v", diags)
+	}
+
+	if state.Attributes["value_ids.%"] != "2" || state.Attributes["value_ids.S"] == "" || state.Attributes["value_ids.M"] == "" {
+		t.Fatalf("expected value ids for each value, found %v", state.Attributes)
+	}
+
+	res, err := meta.api.RetrieveCatalogObject(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving item option: %v", err)
+	}
+
+	option := res.Type.(*objects.CatalogItemOption)
+	if option.DisplayName != "Size" || len(option.Values) != 2 {
+		t.Fatalf("unexpected item option %+v", option)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning item option: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+}
+
+func TestCatalogItemOptionValues(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	optionResource := resourceCatalogItemOption()
+	r := resourceCatalogItem()
+
+	size, diags := testApply(t, optionResource, meta, nil, map[string]interface{}{
+		"name": "values-size",
+		"value": []interface{}{
+			map[string]interface{}{"name": "S"},
+			map[string]interface{}{"name": "M"},
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating item option: %v", diags)
+	}
+
+	color, diags := testApply(t, optionResource, meta, nil, map[string]interface{}{
+		"name": "values-color",
+		"value": []interface{}{
+			map[string]interface{}{"name": "Red"},
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating item option: %v", diags)
+	}
+
+	variation := func(name, valueID string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":         name,
+			"pricing_type": "VARIABLE_PRICING",
+			"item_option_value": []interface{}{
+				map[string]interface{}{"item_option_id": size.ID, "item_option_value_id": valueID},
+			},
+		}
+	}
+
+	config := map[string]interface{}{
+		"name":            "values-item",
+		"item_option_ids": []interface{}{size.ID},
+		"variation": []interface{}{
+			variation("small", size.Attributes["value_ids.S"]),
+			variation("medium", size.Attributes["value_ids.M"]),
+		},
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating item: %v", diags)
+	}
+
+	res, err := meta.api.RetrieveCatalogObject(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving item: %v", err)
+	}
+
+	item := res.Type.(*objects.CatalogItem)
+	if len(item.ItemOptions) != 1 || item.ItemOptions[0].ItemOptionID != size.ID {
+		t.Fatalf("unexpected item options %+v", item.ItemOptions)
+	}
+
+	for _, vo := range item.Variations {
+		v := vo.Type.(*objects.CatalogItemVariation)
+		if len(v.ItemOptionValues) != 1 || v.ItemOptionValues[0].ItemOptionID != size.ID {
+			t.Fatalf("unexpected item option values %+v", v.ItemOptionValues)
+		}
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning item: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+
+	// Checking the values retrieves just the item option, rather than loading the whole catalog into the cache.
+	api, counts := testCountingClient(t)
+
+	counting := *meta
+	counting.api = api
+	counting.cache = newCatalogCache(api)
+	config["name"] = "values-item-counted"
+
+	if _, diags := testApply(t, r, &counting, nil, config); diags.HasError() {
+		t.Fatalf("error creating item: %v", diags)
+	}
+
+	if c := counts(); c["GET catalog/list"] != 0 || c["GET catalog/object/"+size.ID] != 1 {
+		t.Fatalf("expected the item option to be retrieved on its own, found %v", c)
+	}
+
+	tests := map[string]struct {
+		itemOptionIDs []interface{}
+		variation     map[string]interface{}
+		errContains   string
+	}{
+		"value from another option": {
+			itemOptionIDs: []interface{}{size.ID},
+			variation:     variation("red", color.Attributes["value_ids.Red"]),
+			errContains:   "item option value " + color.Attributes["value_ids.Red"] + " is not one of the values of item option " + size.ID,
+		},
+		"option not on the item": {
+			itemOptionIDs: []interface{}{},
+			variation:     variation("small", size.Attributes["value_ids.S"]),
+			errContains:   "is not one of the item's item_option_ids",
+		},
+		"missing option value": {
+			itemOptionIDs: []interface{}{size.ID, color.ID},
+			variation:     variation("small", size.Attributes["value_ids.S"]),
+			errContains:   "missing a value for item option " + color.ID,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			_, diags := testApply(t, r, meta, nil, map[string]interface{}{
+				"name":            "values-invalid-item",
+				"item_option_ids": test.itemOptionIDs,
+				"variation":       []interface{}{test.variation},
+			})
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, test.errContains) {
+				t.Fatalf("expected error containing %q, found %v", test.errContains, diags)
+			}
+		})
+	}
+}
@@ -3,30 +3,32 @@ package main
 import (
 	"context"
 	"fmt"
-	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"testing"
-	"time"
 
-	"github.com/Houndie/square-go"
 	"github.com/Houndie/square-go/catalog"
 	"github.com/Houndie/square-go/objects"
-	"github.com/Houndie/square-go/options"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func providerBlock(token string) string {
+	baseURL := ""
+	if testBaseURL != "" {
+		baseURL = fmt.Sprintf("base_url = %q", testBaseURL)
+	}
+
 	return fmt.Sprintf(`
 provider "square" {
 	access_token = "%s"
 	environment = "sandbox"
+	%s
 }
 
-`, token)
+`, token, baseURL)
 }
 
 const catalogItemBlock = `resource "square_catalog_item" "test_item" {
@@ -49,11 +51,7 @@ const catalogItemBlock = `resource "square_catalog_item" "test_item" {
 func TestCatalogItem(t *testing.T) {
 	t.Parallel()
 
-	token := os.Getenv("TEST_TOKEN")
-	if token == "" {
-		t.Log("Test skipped as TEST_TOKEN not set")
-		t.Skip()
-	}
+	token := testAccToken()
 
 	resource.Test(t, resource.TestCase{
 		ProviderFactories: map[string]func() (*schema.Provider, error){
@@ -88,6 +86,20 @@ func TestCatalogItem(t *testing.T) {
 					checkCatalogItemRemote("square_catalog_item.test_item", token),
 				),
 			},
+			{
+				ResourceName:      "square_catalog_item.test_item",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             providerBlock(token) + catalogItemBlock,
+				Check:              deleteCatalogObjectRemote("square_catalog_item.test_item", token),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: providerBlock(token) + catalogItemBlock,
+				Check:  checkCatalogItemRemote("square_catalog_item.test_item", token),
+			},
 			{
 				Config: providerBlock(token),
 				Check: resource.ComposeTestCheckFunc(
@@ -133,6 +145,10 @@ func compareCatalogItemToResource(d *terraform.InstanceState, s *objects.Catalog
 		return fmt.Errorf("unexpected name")
 	}
 
+	if d.Attributes["category_id"] != item.CategoryID {
+		return fmt.Errorf("unexpected category id")
+	}
+
 	for _, variation := range item.Variations {
 		v, ok := variation.Type.(*objects.CatalogItemVariation)
 		if !ok {
@@ -209,9 +225,7 @@ func checkCatalogItemDoesntExist(resourceName string) resource.TestCheckFunc {
 //nolint:dupl
 func checkCatalogItemDoesntExistRemote(itemName, apiKey string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client, err := square.NewClient(apiKey, objects.Sandbox, options.WithHTTPClient(&http.Client{
-			Timeout: 10 * time.Second,
-		}))
+		client, err := testSquareClient(apiKey)
 		if err != nil {
 			return fmt.Errorf("error creating square client: %w", err)
 		}
@@ -248,9 +262,7 @@ func checkCatalogItemRemote(resourceName, apiKey string) resource.TestCheckFunc
 			return fmt.Errorf("Not found: %s", resourceName)
 		}
 
-		client, err := square.NewClient(apiKey, objects.Sandbox, options.WithHTTPClient(&http.Client{
-			Timeout: 10 * time.Second,
-		}))
+		client, err := testSquareClient(apiKey)
 		if err != nil {
 			return fmt.Errorf("error creating square client: %w", err)
 		}
@@ -266,3 +278,430 @@ func checkCatalogItemRemote(resourceName, apiKey string) resource.TestCheckFunc
 		return compareCatalogItemToResource(rs.Primary, res.Object)
 	}
 }
+
+func TestCatalogItemLocationPresence(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogItem()
+
+	locations, err := meta.api.ListLocations(context.Background())
+	if err != nil {
+		t.Fatalf("error listing locations: %v", err)
+	}
+
+	locationID := locations[0].ID
+
+	config := map[string]interface{}{
+		"name":                     "kiosk-item",
+		"present_at_all_locations": false,
+		"present_at_location_ids":  []interface{}{locationID},
+		"variation": []interface{}{
+			map[string]interface{}{
+				"name":                     "kiosk-variation",
+				"pricing_type":             "VARIABLE_PRICING",
+				"present_at_all_locations": false,
+				"present_at_location_ids":  []interface{}{locationID},
+			},
+		},
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating item: %v", diags)
+	}
+
+	res, err := meta.api.RetrieveCatalogObject(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving item: %v", err)
+	}
+
+	if res.PresentAtAllLocations || len(res.PresentAtLocationIDs) != 1 || res.PresentAtLocationIDs[0] != locationID {
+		t.Fatalf("unexpected item location presence %v %v", res.PresentAtAllLocations, res.PresentAtLocationIDs)
+	}
+
+	variation := res.Type.(*objects.CatalogItem).Variations[0]
+	if variation.PresentAtAllLocations || len(variation.PresentAtLocationIDs) != 1 {
+		t.Fatalf("unexpected variation location presence %v %v", variation.PresentAtAllLocations, variation.PresentAtLocationIDs)
+	}
+
+	if state.Attributes["present_at_all_locations"] != "false" || state.Attributes["present_at_location_ids.#"] != "1" {
+		t.Fatalf("location presence not read back into state: %v", state.Attributes)
+	}
+
+	// Planning the same config again must not produce a diff.
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning item: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+
+	config["present_at_all_locations"] = true
+	if _, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta); err == nil {
+		t.Fatalf("expected error planning present_at_location_ids with present_at_all_locations")
+	}
+}
+
+func TestCatalogItemLocationOverrides(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogItem()
+
+	first, err := meta.api.CreateLocation(context.Background(), &squareapi.Location{Name: "override-location-1"})
+	if err != nil {
+		t.Fatalf("error creating location: %v", err)
+	}
+
+	second, err := meta.api.CreateLocation(context.Background(), &squareapi.Location{Name: "override-location-2"})
+	if err != nil {
+		t.Fatalf("error creating location: %v", err)
+	}
+
+	// Configured out of location id order, to check that the configured order is kept.
+	config := map[string]interface{}{
+		"name": "override-item",
+		"variation": []interface{}{
+			map[string]interface{}{
+				"name":         "override-variation",
+				"pricing_type": "FIXED_PRICING",
+				"amount":       500,
+				"location_override": []interface{}{
+					map[string]interface{}{
+						"location_id":               second.ID,
+						"amount":                    700,
+						"currency":                  "CAD",
+						"track_inventory":           true,
+						"inventory_alert_type":      "LOW_QUANTITY",
+						"inventory_alert_threshold": 5,
+					},
+					map[string]interface{}{
+						"location_id": first.ID,
+						"amount":      600,
+					},
+				},
+			},
+		},
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating item: %v", diags)
+	}
+
+	res, err := meta.api.RetrieveCatalogObject(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving item: %v", err)
+	}
+
+	overrides := res.Type.(*objects.CatalogItem).Variations[0].Type.(*objects.CatalogItemVariation).LocationOverrides
+	if len(overrides) != 2 {
+		t.Fatalf("expected 2 location overrides, found %d", len(overrides))
+	}
+
+	for _, o := range overrides {
+		if o.LocationID != second.ID {
+			continue
+		}
+
+		if o.PriceMoney.Amount != 700 || o.PriceMoney.Currency != "CAD" || !o.TrackInventory {
+			t.Fatalf("unexpected location override %+v", o)
+		}
+
+		if alert, ok := o.InventoryAlertType.(*objects.InventoryAlertTypeLowQuantity); !ok || alert.Threshold != 5 {
+			t.Fatalf("unexpected inventory alert type %+v", o.InventoryAlertType)
+		}
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning item: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+}
+
+func TestLocationOverridesObjectToResourceOrder(t *testing.T) {
+	t.Parallel()
+
+	meta := &providerMeta{defaultCurrency: "USD"}
+	overrides := []*objects.ItemVariationLocationOverrides{
+		{LocationID: "d"},
+		{LocationID: "b"},
+		{LocationID: "c"},
+		{LocationID: "a"},
+	}
+	configured := []interface{}{
+		map[string]interface{}{"location_id": "c", "currency": ""},
+		map[string]interface{}{"location_id": "a", "currency": ""},
+	}
+
+	found := locationOverridesObjectToResource(overrides, configured, meta)
+
+	for i, expected := range []string{"c", "a", "b", "d"} {
+		if id := found[i].(map[string]interface{})["location_id"]; id != expected {
+			t.Fatalf("expected location %s at %d, found %s", expected, i, id)
+		}
+	}
+}
+
+func TestCatalogItemVariationFields(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogItem()
+
+	variation := map[string]interface{}{
+		"name":                      "fields-variation",
+		"pricing_type":              "FIXED_PRICING",
+		"amount":                    500,
+		"sku":                       "SKU-1",
+		"upc":                       "012345678905",
+		"ordinal":                   2,
+		"track_inventory":           true,
+		"inventory_alert_type":      "LOW_QUANTITY",
+		"inventory_alert_threshold": 3,
+		"service_duration":          1800000,
+		"available_for_booking":     true,
+		"sellable":                  false,
+		"stockable":                 true,
+		"measurement_unit_id":       "unit",
+	}
+	config := map[string]interface{}{
+		"name":      "fields-item",
+		"variation": []interface{}{variation},
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating item: %v", diags)
+	}
+
+	res, err := meta.api.RetrieveCatalogObject(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving item: %v", err)
+	}
+
+	vo := res.Type.(*objects.CatalogItem).Variations[0]
+	v := vo.Type.(*objects.CatalogItemVariation)
+
+	if v.SKU != "SKU-1" || v.UPC != "012345678905" || v.Ordinal != 2 || !v.TrackInventory || v.ServiceDuration != 1800000 || !v.AvailableForBooking || v.MeasurementUnitID != "unit" {
+		t.Fatalf("unexpected variation %+v", v)
+	}
+
+	if alert, ok := v.InventoryAlertType.(*objects.InventoryAlertTypeLowQuantity); !ok || alert.Threshold != 3 {
+		t.Fatalf("unexpected inventory alert type %+v", v.InventoryAlertType)
+	}
+
+	if res.ExtraBool(vo.ID, "sellable", true) || !res.ExtraBool(vo.ID, "stockable", false) {
+		t.Fatalf("unexpected sellable and stockable %v", res.Extra[vo.ID])
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning item: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+
+	delete(variation, "sellable")
+	delete(variation, "inventory_alert_type")
+	delete(variation, "inventory_alert_threshold")
+	variation["track_inventory"] = false
+
+	state, diags = testApply(t, r, meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("error updating item: %v", diags)
+	}
+
+	res, err = meta.api.RetrieveCatalogObject(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving item: %v", err)
+	}
+
+	vo = res.Type.(*objects.CatalogItem).Variations[0]
+	v = vo.Type.(*objects.CatalogItemVariation)
+
+	if v.TrackInventory || v.InventoryAlertType != nil || !res.ExtraBool(vo.ID, "sellable", false) {
+		t.Fatalf("expected variation fields to be cleared, found %+v %v", v, res.Extra[vo.ID])
+	}
+}
+
+func TestCatalogItemAttributes(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogItem()
+
+	config := map[string]interface{}{
+		"name":                 "attributes-item",
+		"description":          "An item with every attribute",
+		"abbreviation":         "AI",
+		"label_color":          "9da2a6",
+		"product_type":         "APPOINTMENTS_SERVICE",
+		"skip_modifier_screen": true,
+		"available_online":     true,
+		"available_for_pickup": true,
+		"visibility":           "PUBLIC",
+		"variation": []interface{}{
+			map[string]interface{}{
+				"name":         "attributes-variation",
+				"pricing_type": "VARIABLE_PRICING",
+			},
+		},
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating item: %v", diags)
+	}
+
+	res, err := meta.api.RetrieveCatalogObject(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving item: %v", err)
+	}
+
+	item := res.Type.(*objects.CatalogItem)
+	if item.Description != "An item with every attribute" || item.Abbreviation != "AI" || item.LabelColor != "9da2a6" ||
+		item.ProductType != objects.CatalogItemProductTypeAppointmentsService || !item.SkipModifierScreen || !item.AvailableOnline || !item.AvailableForPickup {
+		t.Fatalf("unexpected item %+v", item)
+	}
+
+	if v := res.ExtraField(res.ID, "visibility"); v != "PUBLIC" {
+		t.Fatalf("expected visibility PUBLIC, found %v", v)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning item: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+
+	// Without product_type or visibility, square's defaults are used.
+	config = map[string]interface{}{
+		"name":      "attributes-item-defaults",
+		"variation": config["variation"],
+	}
+
+	state, diags = testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating item: %v", diags)
+	}
+
+	if state.Attributes["product_type"] != "REGULAR" || state.Attributes["visibility"] != "PRIVATE" {
+		t.Fatalf("unexpected defaults %v", state.Attributes)
+	}
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning item: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+}
+
+func TestCatalogItemAttributesValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		attribute string
+		value     string
+		valid     bool
+	}{
+		"known product type":   {attribute: "product_type", value: "GIFT_CARD", valid: true},
+		"unknown product type": {attribute: "product_type", value: "CAR"},
+		"hex label color":      {attribute: "label_color", value: "9DA2A6", valid: true},
+		"named label color":    {attribute: "label_color", value: "red"},
+		"public visibility":    {attribute: "visibility", value: "PUBLIC", valid: true},
+		"unknown visibility":   {attribute: "visibility", value: "HIDDEN"},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := resourceCatalogItem().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":         "item",
+				test.attribute: test.value,
+				"variation": []interface{}{
+					map[string]interface{}{"name": "variation", "pricing_type": "VARIABLE_PRICING"},
+				},
+			}))
+
+			if diags.HasError() == test.valid {
+				t.Fatalf("expected valid to be %t, found %v", test.valid, diags)
+			}
+		})
+	}
+}
+
+func TestCatalogItemVariationPricingCustomizeDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		variation   map[string]interface{}
+		errContains string
+	}{
+		"fixed pricing": {
+			variation: map[string]interface{}{"pricing_type": "FIXED_PRICING", "amount": 100, "currency": "CAD"},
+		},
+		"variable pricing": {
+			variation: map[string]interface{}{"pricing_type": "VARIABLE_PRICING"},
+		},
+		"variable pricing with amount": {
+			variation:   map[string]interface{}{"pricing_type": "VARIABLE_PRICING", "amount": 100},
+			errContains: "amount can't be set",
+		},
+		"variable pricing with currency": {
+			variation:   map[string]interface{}{"pricing_type": "VARIABLE_PRICING", "currency": "USD"},
+			errContains: "currency can't be set",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			test.variation["name"] = "variation"
+			config := map[string]interface{}{
+				"name":      "item",
+				"variation": []interface{}{test.variation},
+			}
+
+			_, err := resourceCatalogItem().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+
+			switch {
+			case test.errContains == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.errContains != "" && (err == nil || !strings.Contains(err.Error(), test.errContains)):
+				t.Fatalf("expected error containing %q, found %v", test.errContains, err)
+			}
+		})
+	}
+
+	diags := resourceCatalogItem().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "item",
+		"variation": []interface{}{
+			map[string]interface{}{"name": "variation", "pricing_type": "TIERED_PRICING"},
+		},
+	}))
+	if !diags.HasError() {
+		t.Fatalf("expected an unknown pricing_type to be invalid")
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var modifierSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"id": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"amount": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"currency": &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validateCurrency,
+		},
+		"ordinal": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+	},
+}
+
+func resourceCatalogModifierList() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"selection_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(objects.CatalogModifierListSelectionTypeMultiple),
+			},
+			"ordinal": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"modifier": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     modifierSchema,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		CreateContext: resourceCatalogUpsert(catalogModifierListResourceToObject, catalogModifierListObjectToResource),
+		ReadContext:   resourceCatalogRead(catalogModifierListObjectToResource),
+		UpdateContext: resourceCatalogUpsert(catalogModifierListResourceToObject, catalogModifierListObjectToResource),
+		DeleteContext: resourceCatalogDelete(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCatalogImport(catalogModifierListObjectToResource),
+		},
+	}
+}
+
+func catalogModifierListResourceToObject(d *schema.ResourceData, meta *providerMeta) (*squareapi.CatalogObject, error) {
+	id := d.Id()
+	if id == "" {
+		id = "#id"
+	}
+
+	dModifiers := d.Get("modifier").(*schema.Set)
+	modifiers := make([]*objects.CatalogObject, dModifiers.Len())
+
+	for i, m := range dModifiers.List() {
+		mm := m.(map[string]interface{})
+
+		mid := mm["id"].(string)
+		if mid == "" {
+			uid, err := uuid.NewV4()
+			if err != nil {
+				return nil, fmt.Errorf("error generating uuid for new modifier: %w", err)
+			}
+
+			mid = "#" + uid.String()
+		}
+
+		var money *objects.Money
+		if amount := mm["amount"].(int); amount != 0 {
+			money = &objects.Money{
+				Amount:   amount,
+				Currency: meta.currency(mm["currency"].(string)),
+			}
+		}
+
+		modifiers[i] = &objects.CatalogObject{
+			ID: mid,
+			Type: &objects.CatalogModifier{
+				Name:           mm["name"].(string),
+				PriceMoney:     money,
+				Ordinal:        mm["ordinal"].(int),
+				ModifierListID: id,
+			},
+			PresentAtAllLocations: true,
+		}
+	}
+
+	return squareapi.NewCatalogObject(&objects.CatalogObject{
+		ID: id,
+		Type: &objects.CatalogModifierList{
+			Name:          d.Get("name").(string),
+			Ordinal:       d.Get("ordinal").(int),
+			SelectionType: objects.CatalogModifierListSelectionType(d.Get("selection_type").(string)),
+			Modifiers:     modifiers,
+		},
+		Version:               d.Get("version").(int),
+		PresentAtAllLocations: true,
+	}), nil
+}
+
+func catalogModifierListObjectToResource(o *squareapi.CatalogObject, d *schema.ResourceData, meta *providerMeta) error {
+	d.SetId(o.ID)
+
+	modifierList, ok := o.Type.(*objects.CatalogModifierList)
+	if !ok {
+		return fmt.Errorf("catalog object is not a catalog modifier list")
+	}
+
+	if err := d.Set("name", modifierList.Name); err != nil {
+		return fmt.Errorf("error setting name: %w", err)
+	}
+
+	if err := d.Set("selection_type", string(modifierList.SelectionType)); err != nil {
+		return fmt.Errorf("error setting selection type: %w", err)
+	}
+
+	if err := d.Set("ordinal", modifierList.Ordinal); err != nil {
+		return fmt.Errorf("error setting ordinal: %w", err)
+	}
+
+	// Configured modifiers are matched by id, or by name for modifiers that are being created.
+	configuredCurrencies := map[string]string{}
+	configuredCurrenciesByName := map[string]string{}
+
+	for _, m := range d.Get("modifier").(*schema.Set).List() {
+		mm := m.(map[string]interface{})
+		configuredCurrencies[mm["id"].(string)] = mm["currency"].(string)
+		configuredCurrenciesByName[mm["name"].(string)] = mm["currency"].(string)
+	}
+
+	modifiers := make([]interface{}, len(modifierList.Modifiers))
+
+	for i, mo := range modifierList.Modifiers {
+		m, ok := mo.Type.(*objects.CatalogModifier)
+		if !ok {
+			return fmt.Errorf("catalog object is not a catalog modifier")
+		}
+
+		var (
+			amount   int
+			currency string
+		)
+
+		configuredCurrency, ok := configuredCurrencies[mo.ID]
+		if !ok {
+			configuredCurrency = configuredCurrenciesByName[m.Name]
+		}
+
+		if m.PriceMoney != nil {
+			amount = m.PriceMoney.Amount
+			currency = meta.stateCurrency(m.PriceMoney.Currency, configuredCurrency)
+		}
+
+		modifiers[i] = map[string]interface{}{
+			"id":       mo.ID,
+			"name":     m.Name,
+			"amount":   amount,
+			"currency": currency,
+			"ordinal":  m.Ordinal,
+		}
+	}
+
+	if err := d.Set("modifier", schema.NewSet(schema.HashResource(modifierSchema), modifiers)); err != nil {
+		return fmt.Errorf("error setting modifiers: %w", err)
+	}
+
+	if err := d.Set("version", o.Version); err != nil {
+		return fmt.Errorf("error setting version: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Houndie/square-go/catalog"
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const catalogModifierListBlock = `resource "square_catalog_modifier_list" "test_modifier_list" {
+	name = "my-modifier-list"
+	selection_type = "MULTIPLE"
+
+	modifier {
+		name = "extra cheese"
+		amount = 150
+		ordinal = 1
+	}
+
+	modifier {
+		name = "no onions"
+		ordinal = 2
+	}
+}
+
+`
+
+const catalogModifierListItemBlock = `resource "square_catalog_item" "test_modifier_list_item" {
+	name = "my-modifier-list-item"
+
+	modifier_list_info {
+		modifier_list_id = square_catalog_modifier_list.test_modifier_list.id
+		min_selected_modifiers = 0
+		max_selected_modifiers = 2
+	}
+
+	variation {
+		name = "variation1"
+		pricing_type = "FIXED_PRICING"
+		amount = 500
+	}
+}
+
+`
+
+func TestCatalogModifierList(t *testing.T) {
+	t.Parallel()
+
+	token := testAccToken()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"square": func() (*schema.Provider, error) { return Provider(), nil }, //nolint:unparam
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerBlock(token) + catalogModifierListBlock,
+				Check: resource.ComposeTestCheckFunc(
+					checkCatalogModifierList("square_catalog_modifier_list.test_modifier_list", &objects.CatalogObject{
+						Type: &objects.CatalogModifierList{
+							Name:          "my-modifier-list",
+							SelectionType: objects.CatalogModifierListSelectionTypeMultiple,
+							Modifiers: []*objects.CatalogObject{
+								{
+									Type: &objects.CatalogModifier{
+										Name: "extra cheese",
+										PriceMoney: &objects.Money{
+											Amount: 150,
+										},
+										Ordinal: 1,
+									},
+								},
+								{
+									Type: &objects.CatalogModifier{
+										Name:    "no onions",
+										Ordinal: 2,
+									},
+								},
+							},
+						},
+					}),
+					checkCatalogModifierListRemote("square_catalog_modifier_list.test_modifier_list", token),
+				),
+			},
+			{
+				Config: providerBlock(token) + catalogModifierListBlock + catalogModifierListItemBlock,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item.test_modifier_list_item", "modifier_list_info.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(
+						"square_catalog_item.test_modifier_list_item", "modifier_list_info.*.modifier_list_id",
+						"square_catalog_modifier_list.test_modifier_list", "id",
+					),
+					resource.TestCheckTypeSetElemNestedAttrs("square_catalog_item.test_modifier_list_item", "modifier_list_info.*", map[string]string{
+						"max_selected_modifiers": "2",
+						"enabled":                "true",
+					}),
+					checkCatalogItemRemote("square_catalog_item.test_modifier_list_item", token),
+				),
+			},
+			{
+				Config: providerBlock(token),
+				Check: resource.ComposeTestCheckFunc(
+					checkCatalogModifierListDoesntExist("square_catalog_modifier_list.test_modifier_list"),
+					checkCatalogModifierListDoesntExistRemote("my-modifier-list", token),
+				),
+			},
+		},
+	})
+}
+
+func checkCatalogModifierList(resourceName string, expected *objects.CatalogObject) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("Widget ID is not set")
+		}
+
+		return compareCatalogModifierListToResource(rs.Primary, expected)
+	}
+}
+
+func compareCatalogModifierListToResource(d *terraform.InstanceState, s *objects.CatalogObject) error {
+	if s.ID != "" && d.ID != s.ID {
+		return fmt.Errorf("unexpected id")
+	}
+
+	if strings.HasPrefix(d.ID, "#") {
+		return fmt.Errorf("no id assigned from server")
+	}
+
+	modifierList, ok := s.Type.(*objects.CatalogModifierList)
+	if !ok {
+		return fmt.Errorf("provided catalog object is not a modifier list")
+	}
+
+	if d.Attributes["name"] != modifierList.Name {
+		return fmt.Errorf("unexpected name")
+	}
+
+	if d.Attributes["selection_type"] != string(modifierList.SelectionType) {
+		return fmt.Errorf("unexpected selection type")
+	}
+
+	for _, modifier := range modifierList.Modifiers {
+		m, ok := modifier.Type.(*objects.CatalogModifier)
+		if !ok {
+			return fmt.Errorf("provided catalog object is not a modifier")
+		}
+
+		i := 0
+		found := false
+
+		for ; i < len(modifierList.Modifiers); i++ {
+			if d.Attributes[fmt.Sprintf("modifier.%d.name", i)] == m.Name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("unable to find terraform modifier matching square modifier name")
+		}
+
+		mID := d.Attributes[fmt.Sprintf("modifier.%d.id", i)]
+		if strings.HasPrefix(mID, "#") {
+			return fmt.Errorf("no modifier id assigned from the server")
+		}
+
+		if modifier.ID != "" && mID != modifier.ID {
+			return fmt.Errorf("unexpected modifier id")
+		}
+
+		amount, err := strconv.Atoi(d.Attributes[fmt.Sprintf("modifier.%d.amount", i)])
+		if err != nil {
+			return fmt.Errorf("terraform amount is not an int")
+		}
+
+		expectedAmount := 0
+		if m.PriceMoney != nil {
+			expectedAmount = m.PriceMoney.Amount
+		}
+
+		if amount != expectedAmount {
+			return fmt.Errorf("unexpected amount")
+		}
+
+		if d.Attributes[fmt.Sprintf("modifier.%d.ordinal", i)] != strconv.Itoa(m.Ordinal) {
+			return fmt.Errorf("unexpected ordinal")
+		}
+	}
+
+	return nil
+}
+
+func checkCatalogModifierListDoesntExist(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		_, ok := s.RootModule().Resources[resourceName]
+		if ok {
+			return fmt.Errorf("Found: %s", resourceName)
+		}
+
+		return nil
+	}
+}
+
+//nolint:dupl
+func checkCatalogModifierListDoesntExistRemote(modifierListName, apiKey string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := testSquareClient(apiKey)
+		if err != nil {
+			return fmt.Errorf("error creating square client: %w", err)
+		}
+
+		res, err := client.Catalog.List(context.Background(), &catalog.ListRequest{
+			Types: []objects.CatalogObjectEnumType{objects.CatalogObjectEnumTypeModifierList},
+		})
+		if err != nil {
+			return fmt.Errorf("error listing all catalog modifier lists: %w", err)
+		}
+
+		for res.Objects.Next() {
+			v := res.Objects.Value()
+
+			modifierList, ok := v.Object.Type.(*objects.CatalogModifierList)
+			if !ok {
+				return fmt.Errorf("object is not a catalog modifier list")
+			}
+
+			if modifierList.Name == modifierListName {
+				return fmt.Errorf("found catalog modifier list that should be deleted")
+			}
+		}
+
+		return nil
+	}
+}
+
+func checkCatalogModifierListRemote(resourceName, apiKey string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		client, err := testSquareClient(apiKey)
+		if err != nil {
+			return fmt.Errorf("error creating square client: %w", err)
+		}
+
+		res, err := client.Catalog.RetrieveObject(context.Background(), &catalog.RetrieveObjectRequest{
+			ObjectID: rs.Primary.ID,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error retrieving remote object: %w", err)
+		}
+
+		return compareCatalogModifierListToResource(rs.Primary, res.Object)
+	}
+}
+
+func TestCatalogModifierListCurrency(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogModifierList()
+
+	config := map[string]interface{}{
+		"name": "currency-modifier-list",
+		"modifier": []interface{}{
+			map[string]interface{}{"name": "explicit", "amount": 100, "currency": meta.defaultCurrency},
+			map[string]interface{}{"name": "default", "amount": 200},
+		},
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating modifier list: %v", diags)
+	}
+
+	currencies := map[string]string{}
+
+	for k, v := range state.Attributes {
+		if strings.HasPrefix(k, "modifier.") && strings.HasSuffix(k, ".name") {
+			currencies[v] = state.Attributes[strings.TrimSuffix(k, "name")+"currency"]
+		}
+	}
+
+	if currencies["explicit"] != meta.defaultCurrency || currencies["default"] != "" {
+		t.Fatalf("expected only the explicit modifier to keep its currency, found %v", currencies)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning modifier list: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+}
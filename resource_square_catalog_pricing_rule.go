@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	pricingRuleDateRegexp      = regexp.MustCompile(`^[0-9]{4}-(0[1-9]|1[0-2])-(0[1-9]|[12][0-9]|3[01])$`)
+	pricingRuleLocalTimeRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]$`)
+)
+
+// pricingRuleExtraFields are pricing rule fields square-go doesn't support, which are sent and read through
+// the catalog object's Extra.
+var pricingRuleExtraFields = []string{"apply_products_id", "valid_from_date", "valid_until_date"}
+
+func resourceCatalogPricingRule() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"discount_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"match_products_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"apply_products_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"exclude_products_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"exclude_strategy": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"exclude_products_id"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					string(objects.ExcludeStrategyLeastExpensive),
+					string(objects.ExcludeStrategyMostExpensive),
+				}, false)),
+			},
+			"time_period_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"valid_from_date": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(pricingRuleDateRegexp, "must be a date in the format YYYY-MM-DD")),
+			},
+			"valid_from_local_time": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(pricingRuleLocalTimeRegexp, "must be a time in the format HH:MM:SS")),
+			},
+			"valid_until_date": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(pricingRuleDateRegexp, "must be a date in the format YYYY-MM-DD")),
+			},
+			"valid_until_local_time": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(pricingRuleLocalTimeRegexp, "must be a time in the format HH:MM:SS")),
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		CustomizeDiff: customizeDiffPricingRuleDates,
+		CreateContext: resourceCatalogUpsert(catalogPricingRuleResourceToObject, catalogPricingRuleObjectToResource),
+		ReadContext:   resourceCatalogRead(catalogPricingRuleObjectToResource),
+		UpdateContext: resourceCatalogUpsert(catalogPricingRuleResourceToObject, catalogPricingRuleObjectToResource),
+		DeleteContext: resourceCatalogDelete(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCatalogImport(catalogPricingRuleObjectToResource),
+		},
+	}
+}
+
+// customizeDiffPricingRuleDates checks that a pricing rule doesn't end before it starts.
+func customizeDiffPricingRuleDates(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("valid_from_date") || !d.NewValueKnown("valid_until_date") {
+		return nil
+	}
+
+	// Dates in YYYY-MM-DD order compare correctly as strings.
+	from, until := d.Get("valid_from_date").(string), d.Get("valid_until_date").(string)
+	if from != "" && until != "" && from > until {
+		return fmt.Errorf("valid_from_date %s is after valid_until_date %s", from, until)
+	}
+
+	return nil
+}
+
+func catalogPricingRuleResourceToObject(d *schema.ResourceData, meta *providerMeta) (*squareapi.CatalogObject, error) {
+	id := d.Id()
+	if id == "" {
+		id = "#id"
+	}
+
+	o := squareapi.NewCatalogObject(&objects.CatalogObject{
+		ID: id,
+		Type: &objects.CatalogPricingRule{
+			Name:                d.Get("name").(string),
+			DiscountID:          d.Get("discount_id").(string),
+			MatchProductsID:     d.Get("match_products_id").(string),
+			ExcludeProductsID:   d.Get("exclude_products_id").(string),
+			ExcludeStrategy:     objects.ExcludeStrategy(d.Get("exclude_strategy").(string)),
+			TimePeriodIDs:       stringSet(d.Get("time_period_ids")),
+			ValidFromLocalTime:  d.Get("valid_from_local_time").(string),
+			ValidUntilLocalTime: d.Get("valid_until_local_time").(string),
+		},
+		Version:               d.Get("version").(int),
+		PresentAtAllLocations: true,
+	})
+
+	for _, field := range pricingRuleExtraFields {
+		if v := d.Get(field).(string); v != "" {
+			o.SetExtraField(id, field, v)
+		}
+	}
+
+	return o, nil
+}
+
+func catalogPricingRuleObjectToResource(o *squareapi.CatalogObject, d *schema.ResourceData, meta *providerMeta) error {
+	d.SetId(o.ID)
+
+	rule, ok := o.Type.(*objects.CatalogPricingRule)
+	if !ok {
+		return fmt.Errorf("catalog object is not a catalog pricing rule")
+	}
+
+	if err := d.Set("name", rule.Name); err != nil {
+		return fmt.Errorf("error setting name: %w", err)
+	}
+
+	if err := d.Set("discount_id", rule.DiscountID); err != nil {
+		return fmt.Errorf("error setting discount id: %w", err)
+	}
+
+	if err := d.Set("match_products_id", rule.MatchProductsID); err != nil {
+		return fmt.Errorf("error setting match products id: %w", err)
+	}
+
+	if err := d.Set("exclude_products_id", rule.ExcludeProductsID); err != nil {
+		return fmt.Errorf("error setting exclude products id: %w", err)
+	}
+
+	if err := d.Set("exclude_strategy", string(rule.ExcludeStrategy)); err != nil {
+		return fmt.Errorf("error setting exclude strategy: %w", err)
+	}
+
+	if err := d.Set("time_period_ids", rule.TimePeriodIDs); err != nil {
+		return fmt.Errorf("error setting time period ids: %w", err)
+	}
+
+	if err := d.Set("valid_from_local_time", rule.ValidFromLocalTime); err != nil {
+		return fmt.Errorf("error setting valid from local time: %w", err)
+	}
+
+	if err := d.Set("valid_until_local_time", rule.ValidUntilLocalTime); err != nil {
+		return fmt.Errorf("error setting valid until local time: %w", err)
+	}
+
+	for _, field := range pricingRuleExtraFields {
+		v, _ := o.ExtraField(o.ID, field).(string)
+		if err := d.Set(field, v); err != nil {
+			return fmt.Errorf("error setting %s: %w", field, err)
+		}
+	}
+
+	if err := d.Set("version", o.Version); err != nil {
+		return fmt.Errorf("error setting version: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCatalogPricingRule(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogPricingRule()
+
+	discount, diags := testApply(t, resourceCatalogDiscount(), meta, nil, map[string]interface{}{
+		"name":       "happy-hour-discount",
+		"type":       catalogDiscountFixedPercentage,
+		"percentage": "25",
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating discount: %v", diags)
+	}
+
+	item, diags := testApply(t, resourceCatalogItem(), meta, nil, map[string]interface{}{
+		"name": "happy-hour-item",
+		"variation": []interface{}{
+			map[string]interface{}{"name": "pint", "pricing_type": "VARIABLE_PRICING"},
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating item: %v", diags)
+	}
+
+	productSet, diags := testApply(t, resourceCatalogProductSet(), meta, nil, map[string]interface{}{
+		"product_ids_any": []interface{}{item.ID},
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating product set: %v", diags)
+	}
+
+	timePeriod, diags := testApply(t, resourceCatalogTimePeriod(), meta, nil, map[string]interface{}{
+		"event": testHappyHourEvent,
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating time period: %v", diags)
+	}
+
+	config := map[string]interface{}{
+		"name":                   "happy-hour",
+		"discount_id":            discount.ID,
+		"match_products_id":      productSet.ID,
+		"time_period_ids":        []interface{}{timePeriod.ID},
+		"valid_from_date":        "2021-06-01",
+		"valid_until_date":       "2021-08-31",
+		"valid_until_local_time": "23:00:00",
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating pricing rule: %v", diags)
+	}
+
+	res, err := meta.api.RetrieveCatalogObject(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving pricing rule: %v", err)
+	}
+
+	rule := res.Type.(*objects.CatalogPricingRule)
+	if rule.DiscountID != discount.ID || rule.MatchProductsID != productSet.ID || len(rule.TimePeriodIDs) != 1 || rule.TimePeriodIDs[0] != timePeriod.ID {
+		t.Fatalf("unexpected pricing rule %+v", rule)
+	}
+
+	if v := res.ExtraField(res.ID, "valid_from_date"); v != "2021-06-01" {
+		t.Fatalf("expected valid_from_date to be sent, found %v", v)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning pricing rule: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+
+	config["valid_from_date"] = "2021-09-01"
+
+	if _, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta); err == nil || !strings.Contains(err.Error(), "is after valid_until_date") {
+		t.Fatalf("expected plan error for a from date after the until date, found %v", err)
+	}
+}
+
+func TestCatalogPricingRuleCustomizeDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config      map[string]interface{}
+		errContains string
+	}{
+		"ordered dates": {config: map[string]interface{}{"valid_from_date": "2021-06-01", "valid_until_date": "2021-08-31"}},
+		"same date":     {config: map[string]interface{}{"valid_from_date": "2021-06-01", "valid_until_date": "2021-06-01"}},
+		"only from":     {config: map[string]interface{}{"valid_from_date": "2021-06-01"}},
+		"reversed dates": {
+			config:      map[string]interface{}{"valid_from_date": "2021-09-01", "valid_until_date": "2021-08-31"},
+			errContains: "valid_from_date 2021-09-01 is after valid_until_date 2021-08-31",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			test.config["name"] = "rule"
+			test.config["discount_id"] = "discount"
+			test.config["match_products_id"] = "set"
+
+			_, err := resourceCatalogPricingRule().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(test.config), nil)
+
+			switch {
+			case test.errContains == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.errContains != "" && (err == nil || !strings.Contains(err.Error(), test.errContains)):
+				t.Fatalf("expected error containing %q, found %v", test.errContains, err)
+			}
+		})
+	}
+}
+
+func TestCatalogPricingRuleValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"valid dates and times": {
+			config: map[string]interface{}{"valid_from_date": "2021-06-01", "valid_from_local_time": "17:00:00"},
+			valid:  true,
+		},
+		"timestamp date":       {config: map[string]interface{}{"valid_from_date": "2021-06-01T00:00:00Z"}},
+		"invalid month":        {config: map[string]interface{}{"valid_until_date": "2021-13-01"}},
+		"time without seconds": {config: map[string]interface{}{"valid_from_local_time": "17:00"}},
+		"invalid hour":         {config: map[string]interface{}{"valid_until_local_time": "24:00:00"}},
+		"unknown strategy":     {config: map[string]interface{}{"exclude_products_id": "set", "exclude_strategy": "CHEAPEST"}},
+		"strategy without set": {config: map[string]interface{}{"exclude_strategy": string(objects.ExcludeStrategyLeastExpensive)}},
+		"strategy with set": {
+			config: map[string]interface{}{"exclude_products_id": "set", "exclude_strategy": string(objects.ExcludeStrategyMostExpensive)},
+			valid:  true,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			test.config["name"] = "rule"
+			test.config["discount_id"] = "discount"
+			test.config["match_products_id"] = "set"
+
+			if diags := resourceCatalogPricingRule().Validate(terraform.NewResourceConfigRaw(test.config)); diags.HasError() == test.valid {
+				t.Fatalf("expected valid to be %t, found %v", test.valid, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var productSetProductKeys = []string{"product_ids_any", "product_ids_all", "all_products"}
+
+func resourceCatalogProductSet() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"product_ids_any": &schema.Schema{
+				Type:         schema.TypeSet,
+				Optional:     true,
+				MinItems:     1,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: productSetProductKeys,
+			},
+			"product_ids_all": &schema.Schema{
+				Type:         schema.TypeSet,
+				Optional:     true,
+				MinItems:     1,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: productSetProductKeys,
+			},
+			"all_products": &schema.Schema{
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ExactlyOneOf: productSetProductKeys,
+			},
+			"quantity_exact": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				ConflictsWith:    []string{"quantity_min", "quantity_max"},
+			},
+			"quantity_min": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"quantity_max": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		CustomizeDiff: customizeDiffProductSetQuantity,
+		CreateContext: resourceCatalogUpsert(catalogProductSetResourceToObject, catalogProductSetObjectToResource),
+		ReadContext:   resourceCatalogRead(catalogProductSetObjectToResource),
+		UpdateContext: resourceCatalogUpsert(catalogProductSetResourceToObject, catalogProductSetObjectToResource),
+		DeleteContext: resourceCatalogDelete(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCatalogImport(catalogProductSetObjectToResource),
+		},
+	}
+}
+
+// customizeDiffProductSetQuantity checks that a product set's quantity range isn't empty.
+func customizeDiffProductSetQuantity(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("quantity_min") || !d.NewValueKnown("quantity_max") {
+		return nil
+	}
+
+	min, max := d.Get("quantity_min").(int), d.Get("quantity_max").(int)
+	if max != 0 && min > max {
+		return fmt.Errorf("quantity_min %d is greater than quantity_max %d", min, max)
+	}
+
+	return nil
+}
+
+func catalogProductSetResourceToObject(d *schema.ResourceData, meta *providerMeta) (*squareapi.CatalogObject, error) {
+	id := d.Id()
+	if id == "" {
+		id = "#id"
+	}
+
+	productSet := &objects.CatalogProductSet{
+		Name: d.Get("name").(string),
+	}
+
+	switch {
+	case d.Get("all_products").(bool):
+		productSet.Products = &objects.CatalogProductSetAllProducts{}
+	case d.Get("product_ids_all").(*schema.Set).Len() > 0:
+		productSet.Products = &objects.CatalogProductSetAllIDs{IDs: stringSet(d.Get("product_ids_all"))}
+	case d.Get("product_ids_any").(*schema.Set).Len() > 0:
+		productSet.Products = &objects.CatalogProductSetAnyIDs{IDs: stringSet(d.Get("product_ids_any"))}
+	default:
+		return nil, fmt.Errorf("one of product_ids_any, product_ids_all, or all_products must be set")
+	}
+
+	min, max := d.Get("quantity_min").(int), d.Get("quantity_max").(int)
+
+	if exact := d.Get("quantity_exact").(int); exact != 0 {
+		productSet.Quantity = &objects.CatalogProductSetQuantityExact{Amount: exact}
+	} else if min != 0 || max != 0 {
+		productSet.Quantity = &objects.CatalogProductSetQuantityRange{Min: min, Max: max}
+	}
+
+	return squareapi.NewCatalogObject(&objects.CatalogObject{
+		ID:                    id,
+		Type:                  productSet,
+		Version:               d.Get("version").(int),
+		PresentAtAllLocations: true,
+	}), nil
+}
+
+func catalogProductSetObjectToResource(o *squareapi.CatalogObject, d *schema.ResourceData, meta *providerMeta) error {
+	d.SetId(o.ID)
+
+	productSet, ok := o.Type.(*objects.CatalogProductSet)
+	if !ok {
+		return fmt.Errorf("catalog object is not a catalog product set")
+	}
+
+	if err := d.Set("name", productSet.Name); err != nil {
+		return fmt.Errorf("error setting name: %w", err)
+	}
+
+	var (
+		allProducts bool
+		idsAll      []string
+		idsAny      []string
+	)
+
+	switch t := productSet.Products.(type) {
+	case *objects.CatalogProductSetAllProducts:
+		allProducts = true
+	case *objects.CatalogProductSetAllIDs:
+		idsAll = t.IDs
+	case *objects.CatalogProductSetAnyIDs:
+		idsAny = t.IDs
+	default:
+		return fmt.Errorf("unknown product set products type %T", t)
+	}
+
+	if err := d.Set("all_products", allProducts); err != nil {
+		return fmt.Errorf("error setting all products: %w", err)
+	}
+
+	if err := d.Set("product_ids_all", idsAll); err != nil {
+		return fmt.Errorf("error setting product ids all: %w", err)
+	}
+
+	if err := d.Set("product_ids_any", idsAny); err != nil {
+		return fmt.Errorf("error setting product ids any: %w", err)
+	}
+
+	var exact, min, max int
+
+	switch t := productSet.Quantity.(type) {
+	case *objects.CatalogProductSetQuantityExact:
+		exact = t.Amount
+	case *objects.CatalogProductSetQuantityRange:
+		min, max = t.Min, t.Max
+	}
+
+	if err := d.Set("quantity_exact", exact); err != nil {
+		return fmt.Errorf("error setting quantity exact: %w", err)
+	}
+
+	if err := d.Set("quantity_min", min); err != nil {
+		return fmt.Errorf("error setting quantity min: %w", err)
+	}
+
+	if err := d.Set("quantity_max", max); err != nil {
+		return fmt.Errorf("error setting quantity max: %w", err)
+	}
+
+	if err := d.Set("version", o.Version); err != nil {
+		return fmt.Errorf("error setting version: %w", err)
+	}
+
+	return nil
+}
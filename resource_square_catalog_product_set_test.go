@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCatalogProductSet(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogProductSet()
+
+	category, diags := testApplyCatalogCategory(t, meta, nil, "product-set-category")
+	if diags.HasError() {
+		t.Fatalf("error creating category: %v", diags)
+	}
+
+	tests := map[string]struct {
+		config   map[string]interface{}
+		products objects.CatalogProductSetProduct
+		quantity objects.CatalogProductSetQuantity
+	}{
+		"any with exact quantity": {
+			config: map[string]interface{}{
+				"name":            "buy-two",
+				"product_ids_any": []interface{}{category.ID},
+				"quantity_exact":  2,
+			},
+			products: &objects.CatalogProductSetAnyIDs{IDs: []string{category.ID}},
+			quantity: &objects.CatalogProductSetQuantityExact{Amount: 2},
+		},
+		"all with range": {
+			config: map[string]interface{}{
+				"product_ids_all": []interface{}{category.ID},
+				"quantity_min":    1,
+				"quantity_max":    3,
+			},
+			products: &objects.CatalogProductSetAllIDs{IDs: []string{category.ID}},
+			quantity: &objects.CatalogProductSetQuantityRange{Min: 1, Max: 3},
+		},
+		"all products": {
+			config: map[string]interface{}{
+				"all_products": true,
+			},
+			products: &objects.CatalogProductSetAllProducts{},
+			quantity: &objects.CatalogProductSetQuantityRange{},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			state, diags := testApply(t, r, meta, nil, test.config)
+			if diags.HasError() {
+				t.Fatalf("error creating product set: %v", diags)
+			}
+
+			res, err := meta.api.RetrieveCatalogObject(context.Background(), state.ID)
+			if err != nil {
+				t.Fatalf("error retrieving product set: %v", err)
+			}
+
+			productSet := res.Type.(*objects.CatalogProductSet)
+			if !reflect.DeepEqual(productSet.Products, test.products) || !reflect.DeepEqual(productSet.Quantity, test.quantity) {
+				t.Fatalf("unexpected product set %+v %+v", productSet.Products, productSet.Quantity)
+			}
+
+			diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(test.config), meta)
+			if err != nil {
+				t.Fatalf("error planning product set: %v", err)
+			}
+
+			if diff != nil && !diff.Empty() {
+				t.Fatalf("unexpected diff after apply: %v", diff)
+			}
+		})
+	}
+}
+
+func TestCatalogProductSetValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"any":         {config: map[string]interface{}{"product_ids_any": []interface{}{"item"}}, valid: true},
+		"no products": {config: map[string]interface{}{"quantity_exact": 1}},
+		"any and all": {config: map[string]interface{}{
+			"product_ids_any": []interface{}{"item"},
+			"product_ids_all": []interface{}{"item"},
+		}},
+		"exact and range": {config: map[string]interface{}{
+			"product_ids_any": []interface{}{"item"},
+			"quantity_exact":  1,
+			"quantity_min":    1,
+		}},
+		"zero quantity": {config: map[string]interface{}{
+			"product_ids_any": []interface{}{"item"},
+			"quantity_exact":  0,
+		}},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diags := resourceCatalogProductSet().Validate(terraform.NewResourceConfigRaw(test.config)); diags.HasError() == test.valid {
+				t.Fatalf("expected valid to be %t, found %v", test.valid, diags)
+			}
+		})
+	}
+}
+
+func TestCatalogProductSetCustomizeDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config      map[string]interface{}
+		errContains string
+	}{
+		"range":       {config: map[string]interface{}{"quantity_min": 1, "quantity_max": 3}},
+		"only min":    {config: map[string]interface{}{"quantity_min": 3}},
+		"equal range": {config: map[string]interface{}{"quantity_min": 2, "quantity_max": 2}},
+		"empty range": {
+			config:      map[string]interface{}{"quantity_min": 3, "quantity_max": 2},
+			errContains: "quantity_min 3 is greater than quantity_max 2",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			test.config["product_ids_any"] = []interface{}{"item"}
+
+			_, err := resourceCatalogProductSet().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(test.config), nil)
+
+			switch {
+			case test.errContains == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.errContains != "" && (err == nil || !strings.Contains(err.Error(), test.errContains)):
+				t.Fatalf("expected error containing %q, found %v", test.errContains, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCatalogTax() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"calculation_phase": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					string(objects.TaxCalculationPhaseSubtotalPhase),
+					string(objects.TaxCalculationPhaseTotalPhase),
+				}, false)),
+			},
+			"inclusion_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					string(objects.TaxInclusionTypeAdditive),
+					string(objects.TaxInclusionTypeInclusive),
+				}, false)),
+			},
+			"percentage": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validatePercentage,
+			},
+			"applies_to_custom_amounts": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		CreateContext: resourceCatalogUpsert(catalogTaxResourceToObject, catalogTaxObjectToResource),
+		ReadContext:   resourceCatalogRead(catalogTaxObjectToResource),
+		UpdateContext: resourceCatalogUpsert(catalogTaxResourceToObject, catalogTaxObjectToResource),
+		DeleteContext: resourceCatalogDelete(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCatalogImport(catalogTaxObjectToResource),
+		},
+	}
+}
+
+func catalogTaxResourceToObject(d *schema.ResourceData, meta *providerMeta) (*squareapi.CatalogObject, error) {
+	id := d.Id()
+	if id == "" {
+		id = "#id"
+	}
+
+	o := squareapi.NewCatalogObject(&objects.CatalogObject{
+		ID: id,
+		Type: &objects.CatalogTax{
+			Name:                   d.Get("name").(string),
+			CalculationPhase:       objects.TaxCalculationPhase(d.Get("calculation_phase").(string)),
+			InclusionType:          objects.TaxInclusionType(d.Get("inclusion_type").(string)),
+			Percentage:             d.Get("percentage").(string),
+			AppliesToCustomAmounts: d.Get("applies_to_custom_amounts").(bool),
+		},
+		Version:               d.Get("version").(int),
+		PresentAtAllLocations: true,
+	})
+
+	// square-go omits enabled when it's false, which square treats as true, so it's sent as an extra field.
+	o.SetExtraField(id, "enabled", d.Get("enabled").(bool))
+
+	return o, nil
+}
+
+func catalogTaxObjectToResource(o *squareapi.CatalogObject, d *schema.ResourceData, meta *providerMeta) error {
+	d.SetId(o.ID)
+
+	tax, ok := o.Type.(*objects.CatalogTax)
+	if !ok {
+		return fmt.Errorf("catalog object is not a catalog tax")
+	}
+
+	if err := d.Set("name", tax.Name); err != nil {
+		return fmt.Errorf("error setting name: %w", err)
+	}
+
+	if err := d.Set("calculation_phase", string(tax.CalculationPhase)); err != nil {
+		return fmt.Errorf("error setting calculation phase: %w", err)
+	}
+
+	if err := d.Set("inclusion_type", string(tax.InclusionType)); err != nil {
+		return fmt.Errorf("error setting inclusion type: %w", err)
+	}
+
+	if err := d.Set("percentage", tax.Percentage); err != nil {
+		return fmt.Errorf("error setting percentage: %w", err)
+	}
+
+	if err := d.Set("applies_to_custom_amounts", tax.AppliesToCustomAmounts); err != nil {
+		return fmt.Errorf("error setting applies to custom amounts: %w", err)
+	}
+
+	if err := d.Set("enabled", o.ExtraBool(o.ID, "enabled", true)); err != nil {
+		return fmt.Errorf("error setting enabled: %w", err)
+	}
+
+	if err := d.Set("version", o.Version); err != nil {
+		return fmt.Errorf("error setting version: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Houndie/square-go/catalog"
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const catalogTaxBlock = `resource "square_catalog_tax" "test_tax" {
+	name = "my-tax"
+	calculation_phase = "TAX_SUBTOTAL_PHASE"
+	inclusion_type = "ADDITIVE"
+	percentage = "7.25"
+	applies_to_custom_amounts = true
+}
+
+`
+
+const catalogTaxItemBlock = `resource "square_catalog_item" "test_tax_item" {
+	name = "my-tax-item"
+	tax_ids = [square_catalog_tax.test_tax.id]
+
+	variation {
+		name = "variation1"
+		pricing_type = "FIXED_PRICING"
+		amount = 500
+	}
+}
+
+`
+
+func TestCatalogTax(t *testing.T) {
+	t.Parallel()
+
+	token := testAccToken()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"square": func() (*schema.Provider, error) { return Provider(), nil }, //nolint:unparam
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerBlock(token) + catalogTaxBlock,
+				Check: resource.ComposeTestCheckFunc(
+					checkCatalogTax("square_catalog_tax.test_tax", &objects.CatalogObject{
+						Type: &objects.CatalogTax{
+							Name:                   "my-tax",
+							CalculationPhase:       objects.TaxCalculationPhaseSubtotalPhase,
+							InclusionType:          objects.TaxInclusionTypeAdditive,
+							Percentage:             "7.25",
+							AppliesToCustomAmounts: true,
+							Enabled:                true,
+						},
+					}),
+					checkCatalogTaxRemote("square_catalog_tax.test_tax", token),
+				),
+			},
+			{
+				Config: providerBlock(token) + catalogTaxBlock + catalogTaxItemBlock,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item.test_tax_item", "tax_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(
+						"square_catalog_item.test_tax_item", "tax_ids.*",
+						"square_catalog_tax.test_tax", "id",
+					),
+					checkCatalogItemRemote("square_catalog_item.test_tax_item", token),
+				),
+			},
+			{
+				Config: providerBlock(token),
+				Check: resource.ComposeTestCheckFunc(
+					checkCatalogTaxDoesntExist("square_catalog_tax.test_tax"),
+					checkCatalogTaxDoesntExistRemote("my-tax", token),
+				),
+			},
+		},
+	})
+}
+
+func checkCatalogTax(resourceName string, expected *objects.CatalogObject) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("Widget ID is not set")
+		}
+
+		return compareCatalogTaxToResource(rs.Primary, expected)
+	}
+}
+
+func compareCatalogTaxToResource(d *terraform.InstanceState, s *objects.CatalogObject) error {
+	if s.ID != "" && d.ID != s.ID {
+		return fmt.Errorf("unexpected id")
+	}
+
+	if strings.HasPrefix(d.ID, "#") {
+		return fmt.Errorf("no id assigned from server")
+	}
+
+	tax, ok := s.Type.(*objects.CatalogTax)
+	if !ok {
+		return fmt.Errorf("provided catalog object is not a tax")
+	}
+
+	if d.Attributes["name"] != tax.Name {
+		return fmt.Errorf("unexpected name")
+	}
+
+	if d.Attributes["calculation_phase"] != string(tax.CalculationPhase) {
+		return fmt.Errorf("unexpected calculation phase")
+	}
+
+	if d.Attributes["inclusion_type"] != string(tax.InclusionType) {
+		return fmt.Errorf("unexpected inclusion type")
+	}
+
+	if d.Attributes["percentage"] != tax.Percentage {
+		return fmt.Errorf("unexpected percentage")
+	}
+
+	if d.Attributes["applies_to_custom_amounts"] != strconv.FormatBool(tax.AppliesToCustomAmounts) {
+		return fmt.Errorf("unexpected applies to custom amounts")
+	}
+
+	if d.Attributes["enabled"] != strconv.FormatBool(tax.Enabled) {
+		return fmt.Errorf("unexpected enabled")
+	}
+
+	return nil
+}
+
+func checkCatalogTaxDoesntExist(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		_, ok := s.RootModule().Resources[resourceName]
+		if ok {
+			return fmt.Errorf("Found: %s", resourceName)
+		}
+
+		return nil
+	}
+}
+
+//nolint:dupl
+func checkCatalogTaxDoesntExistRemote(taxName, apiKey string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := testSquareClient(apiKey)
+		if err != nil {
+			return fmt.Errorf("error creating square client: %w", err)
+		}
+
+		res, err := client.Catalog.List(context.Background(), &catalog.ListRequest{
+			Types: []objects.CatalogObjectEnumType{objects.CatalogObjectEnumTypeTax},
+		})
+		if err != nil {
+			return fmt.Errorf("error listing all catalog taxes: %w", err)
+		}
+
+		for res.Objects.Next() {
+			v := res.Objects.Value()
+
+			tax, ok := v.Object.Type.(*objects.CatalogTax)
+			if !ok {
+				return fmt.Errorf("object is not a catalog tax")
+			}
+
+			if tax.Name == taxName {
+				return fmt.Errorf("found catalog tax that should be deleted")
+			}
+		}
+
+		return nil
+	}
+}
+
+func checkCatalogTaxRemote(resourceName, apiKey string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// retrieve the resource by name from state
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		client, err := testSquareClient(apiKey)
+		if err != nil {
+			return fmt.Errorf("error creating square client: %w", err)
+		}
+
+		res, err := client.Catalog.RetrieveObject(context.Background(), &catalog.RetrieveObjectRequest{
+			ObjectID: rs.Primary.ID,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error retrieving remote object: %w", err)
+		}
+
+		return compareCatalogTaxToResource(rs.Primary, res.Object)
+	}
+}
+
+func TestCatalogTaxDisabled(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogTax()
+
+	config := map[string]interface{}{
+		"name":       "disabled-tax",
+		"percentage": "5.0",
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating tax: %v", diags)
+	}
+
+	config["enabled"] = false
+
+	state, diags = testApply(t, r, meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("error updating tax: %v", diags)
+	}
+
+	res, err := meta.api.RetrieveCatalogObject(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving tax: %v", err)
+	}
+
+	if enabled, ok := res.ExtraField(res.ID, "enabled").(bool); !ok || enabled {
+		t.Fatalf("expected tax to be disabled, found %v", res.Extra[res.ID])
+	}
+
+	if state.Attributes["enabled"] != "false" {
+		t.Fatalf("expected enabled to be false in state, found %v", state.Attributes)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning tax: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCatalogTimePeriod() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"event": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateICalendarEvent,
+				DiffSuppressFunc: suppressICalendarEventDiff,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		CreateContext: resourceCatalogUpsert(catalogTimePeriodResourceToObject, catalogTimePeriodObjectToResource),
+		ReadContext:   resourceCatalogRead(catalogTimePeriodObjectToResource),
+		UpdateContext: resourceCatalogUpsert(catalogTimePeriodResourceToObject, catalogTimePeriodObjectToResource),
+		DeleteContext: resourceCatalogDelete(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCatalogImport(catalogTimePeriodObjectToResource),
+		},
+	}
+}
+
+func catalogTimePeriodResourceToObject(d *schema.ResourceData, meta *providerMeta) (*squareapi.CatalogObject, error) {
+	id := d.Id()
+	if id == "" {
+		id = "#id"
+	}
+
+	return squareapi.NewCatalogObject(&objects.CatalogObject{
+		ID: id,
+		Type: &objects.CatalogTimePeriod{
+			Event: normalizeICalendarEvent(d.Get("event").(string)),
+		},
+		Version:               d.Get("version").(int),
+		PresentAtAllLocations: true,
+	}), nil
+}
+
+func catalogTimePeriodObjectToResource(o *squareapi.CatalogObject, d *schema.ResourceData, meta *providerMeta) error {
+	d.SetId(o.ID)
+
+	timePeriod, ok := o.Type.(*objects.CatalogTimePeriod)
+	if !ok {
+		return fmt.Errorf("catalog object is not a catalog time period")
+	}
+
+	if err := d.Set("event", timePeriod.Event); err != nil {
+		return fmt.Errorf("error setting event: %w", err)
+	}
+
+	if err := d.Set("version", o.Version); err != nil {
+		return fmt.Errorf("error setting version: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testHappyHourEvent = "BEGIN:VEVENT\r\nDTSTART:20210701T170000\r\nDURATION:PT2H\r\nRRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR\r\nEND:VEVENT\r\n"
+
+func TestCatalogTimePeriod(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogTimePeriod()
+
+	config := map[string]interface{}{"event": testHappyHourEvent}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating time period: %v", diags)
+	}
+
+	res, err := meta.api.RetrieveCatalogObject(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving time period: %v", err)
+	}
+
+	if event := res.Type.(*objects.CatalogTimePeriod).Event; event != normalizeICalendarEvent(testHappyHourEvent) {
+		t.Fatalf("expected event to be sent normalized, found %q", event)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning time period: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+}
+
+func TestCatalogTimePeriodValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		event string
+		valid bool
+	}{
+		"happy hour":     {event: testHappyHourEvent, valid: true},
+		"missing start":  {event: "BEGIN:VEVENT\nDURATION:PT2H\nEND:VEVENT"},
+		"not an event":   {event: "every weekday at 5pm"},
+		"bad recurrence": {event: "BEGIN:VEVENT\nDTSTART:20210701T170000\nDURATION:PT2H\nRRULE:BYDAY=MO\nEND:VEVENT"},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]interface{}{"event": test.event}
+
+			if diags := resourceCatalogTimePeriod().Validate(terraform.NewResourceConfigRaw(config)); diags.HasError() == test.valid {
+				t.Fatalf("expected valid to be %t, found %v", test.valid, diags)
+			}
+		})
+	}
+}
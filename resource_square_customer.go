@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// customerBirthdayRegexp matches a birthday with or without its year.
+var customerBirthdayRegexp = regexp.MustCompile(`^([0-9]{4}-)?(0[1-9]|1[0-2])-(0[1-9]|[12][0-9]|3[01])$`)
+
+// customerIdentityFields are the attributes square requires at least one of.
+var customerIdentityFields = []string{"given_name", "family_name", "company_name", "email_address", "phone_number"}
+
+func customerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"given_name": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: customerIdentityFields,
+		},
+		"family_name": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: customerIdentityFields,
+		},
+		"company_name": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: customerIdentityFields,
+		},
+		"email_address": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: customerIdentityFields,
+		},
+		"phone_number": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: customerIdentityFields,
+		},
+		"address": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem:     addressSchema,
+		},
+		"birthday": &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(customerBirthdayRegexp, "expected a birthday in the format YYYY-MM-DD, or MM-DD if the year isn't known")),
+			DiffSuppressFunc: suppressCustomerBirthdayDiff,
+		},
+		"note": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"reference_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+// suppressCustomerBirthdayDiff ignores the year of 0000 square fills in for birthdays given without a year.
+func suppressCustomerBirthdayDiff(k, old, new string, d *schema.ResourceData) bool {
+	return old == "0000-"+new
+}
+
+func resourceCustomer() *schema.Resource {
+	return &schema.Resource{
+		Schema:        customerSchema(),
+		CreateContext: resourceCustomerCreate,
+		ReadContext:   resourceCustomerRead,
+		UpdateContext: resourceCustomerUpdate,
+		DeleteContext: resourceCustomerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceCustomerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	idempotencyKey, err := uuid.NewV4()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating idempotency key: %w", err))
+	}
+
+	customer, err := meta.api.CreateCustomer(ctx, idempotencyKey.String(), customerResourceToObject(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to create customer: %w", err))
+	}
+
+	if err := customerObjectToResource(customer, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	customer, err := meta.api.RetrieveCustomer(ctx, d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] customer %s not found, removing from state", d.Id())
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(fmt.Errorf("error making network call to retrieve customer: %w", err))
+	}
+
+	if err := customerObjectToResource(customer, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// customerClearableFields are the optional attributes that must be cleared explicitly when they're removed,
+// as customer updates leave missing fields untouched.
+var customerClearableFields = []string{
+	"given_name", "family_name", "company_name", "email_address", "phone_number", "address", "birthday", "note", "reference_id",
+}
+
+func resourceCustomerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	clear := []string{}
+
+	for _, field := range customerClearableFields {
+		if _, n := d.GetChange(field); d.HasChange(field) && isEmptyValue(n) {
+			clear = append(clear, field)
+		}
+	}
+
+	customer, err := meta.api.UpdateCustomer(ctx, d.Id(), customerResourceToObject(d), clear)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to update customer: %w", err))
+	}
+
+	if err := customerObjectToResource(customer, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	if err := meta.api.DeleteCustomer(ctx, d.Id()); err != nil && !isNotFound(err) {
+		return diag.FromErr(fmt.Errorf("error making network call to delete customer: %w", err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func customerResourceToObject(d *schema.ResourceData) *squareapi.Customer {
+	customer := &squareapi.Customer{
+		GivenName:    d.Get("given_name").(string),
+		FamilyName:   d.Get("family_name").(string),
+		CompanyName:  d.Get("company_name").(string),
+		EmailAddress: d.Get("email_address").(string),
+		PhoneNumber:  d.Get("phone_number").(string),
+		Birthday:     d.Get("birthday").(string),
+		Note:         d.Get("note").(string),
+		ReferenceID:  d.Get("reference_id").(string),
+	}
+
+	if address := d.Get("address").([]interface{}); len(address) > 0 && address[0] != nil {
+		customer.Address = addressResourceToObject(address[0].(map[string]interface{}))
+	}
+
+	return customer
+}
+
+// customerToMap converts a customer into its terraform representation, keyed by attribute name.
+func customerToMap(c *squareapi.Customer) map[string]interface{} {
+	return map[string]interface{}{
+		"id":            c.ID,
+		"given_name":    c.GivenName,
+		"family_name":   c.FamilyName,
+		"company_name":  c.CompanyName,
+		"email_address": c.EmailAddress,
+		"phone_number":  c.PhoneNumber,
+		"address":       addressObjectToResource(c.Address),
+		"birthday":      c.Birthday,
+		"note":          c.Note,
+		"reference_id":  c.ReferenceID,
+	}
+}
+
+func customerObjectToResource(c *squareapi.Customer, d *schema.ResourceData) error {
+	d.SetId(c.ID)
+
+	for k, v := range customerToMap(c) {
+		if k == "id" {
+			continue
+		}
+
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("error setting %s: %w", k, err)
+		}
+	}
+
+	return nil
+}
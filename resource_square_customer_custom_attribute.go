@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// customerCustomAttributeIDSeparator separates the customer id and key in a custom attribute's id.  Keys can't
+// contain it.
+const customerCustomAttributeIDSeparator = ":"
+
+func resourceCustomerCustomAttribute() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"customer_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"key": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(customAttributeKeyRegexp, "must be at most 60 letters, digits, periods, underscores, or hyphens")),
+			},
+			"value": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				DiffSuppressFunc: suppressEquivalentJSONDiff,
+			},
+		},
+		CreateContext: resourceCustomerCustomAttributeUpsert,
+		ReadContext:   resourceCustomerCustomAttributeRead,
+		UpdateContext: resourceCustomerCustomAttributeUpsert,
+		DeleteContext: resourceCustomerCustomAttributeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCustomerCustomAttributeImport,
+		},
+	}
+}
+
+// normalizeJSON returns s re-encoded with its object keys sorted and insignificant whitespace removed.
+func normalizeJSON(s string) (string, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return "", fmt.Errorf("error parsing json: %w", err)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling json: %w", err)
+	}
+
+	return string(b), nil
+}
+
+func suppressEquivalentJSONDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldJSON, err := normalizeJSON(old)
+	if err != nil {
+		return false
+	}
+
+	newJSON, err := normalizeJSON(new)
+	if err != nil {
+		return false
+	}
+
+	return oldJSON == newJSON
+}
+
+func resourceCustomerCustomAttributeUpsert(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	idempotencyKey, err := uuid.NewV4()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating idempotency key: %w", err))
+	}
+
+	customerID := d.Get("customer_id").(string)
+
+	attribute, err := meta.api.UpsertCustomerCustomAttribute(ctx, idempotencyKey.String(), customerID, &squareapi.CustomAttribute{
+		Key:   d.Get("key").(string),
+		Value: json.RawMessage(d.Get("value").(string)),
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to upsert customer custom attribute: %w", err))
+	}
+
+	if err := customerCustomAttributeObjectToResource(customerID, attribute, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceCustomerCustomAttributeRead removes the value from state if it's gone, including when the customer or
+// the attribute's definition has been deleted.
+func resourceCustomerCustomAttributeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	customerID := d.Get("customer_id").(string)
+
+	attribute, err := meta.api.RetrieveCustomerCustomAttribute(ctx, customerID, d.Get("key").(string))
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] customer custom attribute %s not found, removing from state", d.Id())
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(fmt.Errorf("error making network call to retrieve customer custom attribute: %w", err))
+	}
+
+	if err := customerCustomAttributeObjectToResource(customerID, attribute, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerCustomAttributeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	err := meta.api.DeleteCustomerCustomAttribute(ctx, d.Get("customer_id").(string), d.Get("key").(string))
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(fmt.Errorf("error making network call to delete customer custom attribute: %w", err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceCustomerCustomAttributeImport imports a value by an id of the form customer_id:key.
+func resourceCustomerCustomAttributeImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), customerCustomAttributeIDSeparator)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid customer custom attribute id %q, expected customer_id%skey", d.Id(), customerCustomAttributeIDSeparator)
+	}
+
+	if err := d.Set("customer_id", parts[0]); err != nil {
+		return nil, fmt.Errorf("error setting customer id: %w", err)
+	}
+
+	if err := d.Set("key", parts[1]); err != nil {
+		return nil, fmt.Errorf("error setting key: %w", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func customerCustomAttributeObjectToResource(customerID string, attribute *squareapi.CustomAttribute, d *schema.ResourceData) error {
+	d.SetId(customerID + customerCustomAttributeIDSeparator + attribute.Key)
+
+	value, err := normalizeJSON(string(attribute.Value))
+	if err != nil {
+		return fmt.Errorf("error reading value: %w", err)
+	}
+
+	if err := d.Set("key", attribute.Key); err != nil {
+		return fmt.Errorf("error setting key: %w", err)
+	}
+
+	if err := d.Set("value", value); err != nil {
+		return fmt.Errorf("error setting value: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// customAttributeSchemaPrefix is the start of the $ref of every schema square supports for custom attributes,
+// other than selections.  The type follows it, as in squareup.common.String.
+const customAttributeSchemaPrefix = "https://developer-production-s.squarecdn.com/schemas/v1/common.json#squareup.common."
+
+// customAttributeSelectionMetaSchema is the $schema of selection schemas, which have no $ref.
+const customAttributeSelectionMetaSchema = "https://developer-production-s.squarecdn.com/meta-schemas/v1/selection.json"
+
+// customAttributeTypes are the types square supports for custom attributes by $ref.
+var customAttributeTypes = []string{"String", "Email", "PhoneNumber", "Address", "Date", "Boolean", "Number"}
+
+var customAttributeKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]{1,60}$`)
+
+var customAttributeVisibilities = []string{"VISIBILITY_HIDDEN", "VISIBILITY_READ_ONLY", "VISIBILITY_READ_WRITE_VALUES"}
+
+func resourceCustomerCustomAttributeDefinition() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(customAttributeKeyRegexp, "must be at most 60 letters, digits, periods, underscores, or hyphens")),
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"schema": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateCustomAttributeSchema,
+				DiffSuppressFunc: suppressCustomAttributeSchemaDiff,
+			},
+			"visibility": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "VISIBILITY_HIDDEN",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(customAttributeVisibilities, false)),
+			},
+			"option_ids": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		CustomizeDiff: customdiff.All(
+			customdiff.ForceNewIfChange("schema", customAttributeSchemaTypeChanged),
+			customdiff.ComputedIf("option_ids", customAttributeSchemaChanged),
+		),
+		CreateContext: resourceCustomerCustomAttributeDefinitionCreate,
+		ReadContext:   resourceCustomerCustomAttributeDefinitionRead,
+		UpdateContext: resourceCustomerCustomAttributeDefinitionUpdate,
+		DeleteContext: resourceCustomerCustomAttributeDefinitionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// parseCustomAttributeSchema checks that s is a json schema of a type square supports, returning the schema and
+// its type.  Selection schemas are recognised by their $schema, and must be arrays that name their options and say
+// how many may be selected.
+func parseCustomAttributeSchema(s string) (map[string]interface{}, string, error) {
+	parsed := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(s), &parsed); err != nil {
+		return nil, "", fmt.Errorf("schema must be a json object: %w", err)
+	}
+
+	if metaSchema, _ := parsed["$schema"].(string); metaSchema != customAttributeSelectionMetaSchema {
+		ref, _ := parsed["$ref"].(string)
+		if !strings.HasPrefix(ref, customAttributeSchemaPrefix) || !containsString(customAttributeTypes, strings.TrimPrefix(ref, customAttributeSchemaPrefix)) {
+			return nil, "", fmt.Errorf("$ref %q is not a supported type, expected %s followed by one of %s, or a $schema of %s for selections", ref, customAttributeSchemaPrefix, strings.Join(customAttributeTypes, ", "), customAttributeSelectionMetaSchema)
+		}
+
+		return parsed, strings.TrimPrefix(ref, customAttributeSchemaPrefix), nil
+	}
+
+	if parsed["type"] != "array" {
+		return nil, "", fmt.Errorf("selection schemas must have type array")
+	}
+
+	items, _ := parsed["items"].(map[string]interface{})
+	names, _ := items["names"].([]interface{})
+
+	if len(names) == 0 {
+		return nil, "", fmt.Errorf("selection schemas must list their options in items.names")
+	}
+
+	seen := map[string]bool{}
+
+	for _, name := range names {
+		n, ok := name.(string)
+		if !ok || n == "" {
+			return nil, "", fmt.Errorf("selection option names must be non-empty strings, found %v", name)
+		}
+
+		if seen[n] {
+			return nil, "", fmt.Errorf("selection option %q is listed more than once", n)
+		}
+
+		seen[n] = true
+	}
+
+	if maxItems, ok := parsed["maxItems"].(float64); !ok || maxItems < 1 || maxItems != float64(int(maxItems)) {
+		return nil, "", fmt.Errorf("selection schemas must set maxItems to the number of options that may be selected")
+	}
+
+	return parsed, "Selection", nil
+}
+
+// customAttributeSchemaString returns a schema as canonical json, without the option ids square adds to
+// selection schemas.
+func customAttributeSchemaString(parsed map[string]interface{}) (string, error) {
+	if items, ok := parsed["items"].(map[string]interface{}); ok {
+		delete(items, "enum")
+	}
+
+	b, err := json.Marshal(parsed)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling schema: %w", err)
+	}
+
+	return string(b), nil
+}
+
+func validateCustomAttributeSchema(i interface{}, path cty.Path) diag.Diagnostics {
+	s, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "expected schema to be a string",
+			AttributePath: path,
+		}}
+	}
+
+	if _, _, err := parseCustomAttributeSchema(s); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid custom attribute schema",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
+
+func suppressCustomAttributeSchemaDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldParsed, _, err := parseCustomAttributeSchema(old)
+	if err != nil {
+		return false
+	}
+
+	newParsed, _, err := parseCustomAttributeSchema(new)
+	if err != nil {
+		return false
+	}
+
+	oldString, err := customAttributeSchemaString(oldParsed)
+	if err != nil {
+		return false
+	}
+
+	newString, err := customAttributeSchemaString(newParsed)
+	if err != nil {
+		return false
+	}
+
+	return oldString == newString
+}
+
+// customAttributeSchemaChanged reports whether a schema changes in a way that may change its option ids.
+func customAttributeSchemaChanged(ctx context.Context, d *schema.ResourceDiff, m interface{}) bool {
+	old, new := d.GetChange("schema")
+
+	return !suppressCustomAttributeSchemaDiff("schema", old.(string), new.(string), nil)
+}
+
+// customAttributeSchemaTypeChanged reports whether a schema changes type, which square doesn't allow without
+// recreating the definition.
+func customAttributeSchemaTypeChanged(ctx context.Context, old, new, m interface{}) bool {
+	_, oldType, oldErr := parseCustomAttributeSchema(old.(string))
+	_, newType, newErr := parseCustomAttributeSchema(new.(string))
+
+	return oldErr == nil && newErr == nil && oldType != newType
+}
+
+func resourceCustomerCustomAttributeDefinitionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	idempotencyKey, err := uuid.NewV4()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating idempotency key: %w", err))
+	}
+
+	definition := customAttributeDefinitionResourceToObject(d)
+	definition.Key = d.Get("key").(string)
+	definition.Schema = json.RawMessage(d.Get("schema").(string))
+
+	definition, err = meta.api.CreateCustomerCustomAttributeDefinition(ctx, idempotencyKey.String(), definition)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to create customer custom attribute definition: %w", err))
+	}
+
+	if err := customAttributeDefinitionObjectToResource(definition, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerCustomAttributeDefinitionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	definition, err := meta.api.RetrieveCustomerCustomAttributeDefinition(ctx, d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] customer custom attribute definition %s not found, removing from state", d.Id())
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(fmt.Errorf("error making network call to retrieve customer custom attribute definition: %w", err))
+	}
+
+	if err := customAttributeDefinitionObjectToResource(definition, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerCustomAttributeDefinitionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	definition := customAttributeDefinitionResourceToObject(d)
+
+	// Square only allows selection schemas to change, so the schema is left out unless it has.
+	if d.HasChange("schema") {
+		definition.Schema = json.RawMessage(d.Get("schema").(string))
+	}
+
+	clear := []string{}
+	if _, n := d.GetChange("description"); d.HasChange("description") && isEmptyValue(n) {
+		clear = append(clear, "description")
+	}
+
+	definition, err := meta.api.UpdateCustomerCustomAttributeDefinition(ctx, d.Id(), definition, clear)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to update customer custom attribute definition: %w", err))
+	}
+
+	if err := customAttributeDefinitionObjectToResource(definition, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerCustomAttributeDefinitionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	if err := meta.api.DeleteCustomerCustomAttributeDefinition(ctx, d.Id()); err != nil && !isNotFound(err) {
+		return diag.FromErr(fmt.Errorf("error making network call to delete customer custom attribute definition: %w", err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func customAttributeDefinitionResourceToObject(d *schema.ResourceData) *squareapi.CustomAttributeDefinition {
+	return &squareapi.CustomAttributeDefinition{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Visibility:  d.Get("visibility").(string),
+	}
+}
+
+func customAttributeDefinitionObjectToResource(definition *squareapi.CustomAttributeDefinition, d *schema.ResourceData) error {
+	d.SetId(definition.Key)
+
+	parsed := map[string]interface{}{}
+	if err := json.Unmarshal(definition.Schema, &parsed); err != nil {
+		return fmt.Errorf("error parsing schema: %w", err)
+	}
+
+	// Square gives each selection option an id, listed in items.enum in the same order as items.names.
+	optionIDs := map[string]interface{}{}
+
+	if items, ok := parsed["items"].(map[string]interface{}); ok {
+		names, _ := items["names"].([]interface{})
+		enum, _ := items["enum"].([]interface{})
+
+		for i, name := range names {
+			if i < len(enum) {
+				optionIDs[fmt.Sprint(name)] = enum[i]
+			}
+		}
+	}
+
+	schemaString, err := customAttributeSchemaString(parsed)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range map[string]interface{}{
+		"key":         definition.Key,
+		"name":        definition.Name,
+		"description": definition.Description,
+		"schema":      schemaString,
+		"visibility":  definition.Visibility,
+		"option_ids":  optionIDs,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("error setting %s: %w", k, err)
+		}
+	}
+
+	return nil
+}
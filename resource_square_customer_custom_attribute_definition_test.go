@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testCustomAttributeSelectionSchema(names string) string {
+	return `{"type": "array", "items": {"type": "string", "names": [` + names + `]}, "maxItems": 1, "uniqueItems": true, "$schema": "` + customAttributeSelectionMetaSchema + `"}`
+}
+
+func TestCustomerCustomAttributeDefinition(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCustomerCustomAttributeDefinition()
+
+	config := map[string]interface{}{
+		"key":         "loyalty-tier",
+		"name":        "Loyalty Tier",
+		"description": "The customer's loyalty program tier",
+		"schema":      testCustomAttributeSelectionSchema(`"Gold", "Silver"`),
+		"visibility":  "VISIBILITY_READ_ONLY",
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating customer custom attribute definition: %v", diags)
+	}
+
+	if state.ID != "loyalty-tier" {
+		t.Fatalf("expected definition to be identified by its key, found %s", state.ID)
+	}
+
+	goldID := state.Attributes["option_ids.Gold"]
+	if goldID == "" || state.Attributes["option_ids.Silver"] == "" {
+		t.Fatalf("expected option ids for every selection option, found %v", state.Attributes)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning customer custom attribute definition: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+
+	config["schema"] = testCustomAttributeSelectionSchema(`"Gold", "Silver", "Bronze"`)
+	delete(config, "description")
+
+	state, diags = testApply(t, r, meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("error updating customer custom attribute definition: %v", diags)
+	}
+
+	if state.Attributes["option_ids.Gold"] != goldID || state.Attributes["option_ids.Bronze"] == "" {
+		t.Fatalf("expected existing options to keep their ids, found %v", state.Attributes)
+	}
+
+	definition, err := meta.api.RetrieveCustomerCustomAttributeDefinition(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving customer custom attribute definition: %v", err)
+	}
+
+	if definition.Description != "" {
+		t.Fatalf("expected removed description to be cleared, found %+v", definition)
+	}
+
+	config["schema"] = `{"$ref": "` + customAttributeSchemaPrefix + `String"}`
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning customer custom attribute definition: %v", err)
+	}
+
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatalf("expected changing the schema type to recreate the definition, found %v", diff)
+	}
+
+	if diags := r.DeleteContext(context.Background(), r.Data(state), meta); diags.HasError() {
+		t.Fatalf("error deleting customer custom attribute definition: %v", diags)
+	}
+
+	state, diags = r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing customer custom attribute definition: %v", diags)
+	}
+
+	if state != nil && state.ID != "" {
+		t.Fatalf("expected deleted definition to be removed from state, found %v", state)
+	}
+}
+
+func TestCustomerCustomAttributeDefinitionValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		schema string
+		key    string
+		valid  bool
+	}{
+		"string":              {schema: `{"$ref": "` + customAttributeSchemaPrefix + `String"}`, valid: true},
+		"boolean":             {schema: `{"$ref": "` + customAttributeSchemaPrefix + `Boolean"}`, valid: true},
+		"selection":           {schema: testCustomAttributeSelectionSchema(`"Gold"`), valid: true},
+		"not json":            {schema: `String`},
+		"unsupported type":    {schema: `{"$ref": "` + customAttributeSchemaPrefix + `Color"}`},
+		"other ref":           {schema: `{"$ref": "https://example.com/schema.json#String"}`},
+		"no options":          {schema: testCustomAttributeSelectionSchema(``)},
+		"duplicate options":   {schema: testCustomAttributeSelectionSchema(`"Gold", "Gold"`)},
+		"selection no limit":  {schema: `{"type": "array", "items": {"type": "string", "names": ["Gold"]}, "$schema": "` + customAttributeSelectionMetaSchema + `"}`},
+		"selection not array": {schema: `{"type": "string", "items": {"type": "string", "names": ["Gold"]}, "maxItems": 1, "$schema": "` + customAttributeSelectionMetaSchema + `"}`},
+		"selection ref":       {schema: `{"$ref": "` + customAttributeSchemaPrefix + `Selection", "items": {"names": ["Gold"]}, "maxItems": 1}`},
+		"key with colon":      {schema: `{"$ref": "` + customAttributeSchemaPrefix + `String"}`, key: "loyalty:tier"},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			key := test.key
+			if key == "" {
+				key = "loyalty-tier"
+			}
+
+			config := map[string]interface{}{
+				"key":    key,
+				"name":   "Loyalty Tier",
+				"schema": test.schema,
+			}
+
+			if diags := resourceCustomerCustomAttributeDefinition().Validate(terraform.NewResourceConfigRaw(config)); diags.HasError() == test.valid {
+				t.Fatalf("expected valid to be %t, found %v", test.valid, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testCustomAttribute creates a customer and a definition with the given schema for setting values on.
+func testCustomAttribute(t *testing.T, meta *providerMeta, key, schema string) (customerID string) {
+	t.Helper()
+
+	customer, err := meta.api.CreateCustomer(context.Background(), "", &squareapi.Customer{GivenName: "Grace"})
+	if err != nil {
+		t.Fatalf("error creating customer: %v", err)
+	}
+
+	if _, err := meta.api.CreateCustomerCustomAttributeDefinition(context.Background(), "", &squareapi.CustomAttributeDefinition{
+		Key:    key,
+		Name:   key,
+		Schema: json.RawMessage(schema),
+	}); err != nil {
+		t.Fatalf("error creating customer custom attribute definition: %v", err)
+	}
+
+	return customer.ID
+}
+
+func TestCustomerCustomAttribute(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCustomerCustomAttribute()
+
+	customerID := testCustomAttribute(t, meta, "account-manager", `{"$ref": "`+customAttributeSchemaPrefix+`String"}`)
+
+	config := map[string]interface{}{
+		"customer_id": customerID,
+		"key":         "account-manager",
+		"value":       ` "Ada Lovelace" `,
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating customer custom attribute: %v", diags)
+	}
+
+	if state.ID != customerID+":account-manager" {
+		t.Fatalf("unexpected custom attribute id %s", state.ID)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning customer custom attribute: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+
+	config["value"] = `"Charles Babbage"`
+
+	state, diags = testApply(t, r, meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("error updating customer custom attribute: %v", diags)
+	}
+
+	attribute, err := meta.api.RetrieveCustomerCustomAttribute(context.Background(), customerID, "account-manager")
+	if err != nil {
+		t.Fatalf("error retrieving customer custom attribute: %v", err)
+	}
+
+	if string(attribute.Value) != `"Charles Babbage"` {
+		t.Fatalf("expected value to be updated, found %s", attribute.Value)
+	}
+
+	config["value"] = `true`
+
+	if _, diags := testApply(t, r, meta, state, config); !diags.HasError() {
+		t.Fatal("expected a value not matching the schema to be rejected")
+	}
+
+	if diags := r.DeleteContext(context.Background(), r.Data(state), meta); diags.HasError() {
+		t.Fatalf("error deleting customer custom attribute: %v", diags)
+	}
+
+	state, diags = r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing customer custom attribute: %v", diags)
+	}
+
+	if state != nil && state.ID != "" {
+		t.Fatalf("expected deleted custom attribute to be removed from state, found %v", state)
+	}
+}
+
+func TestCustomerCustomAttributeSelection(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCustomerCustomAttribute()
+
+	customerID := testCustomAttribute(t, meta, "selection-tier", testCustomAttributeSelectionSchema(`"Gold", "Silver"`))
+
+	definition, err := meta.api.RetrieveCustomerCustomAttributeDefinition(context.Background(), "selection-tier")
+	if err != nil {
+		t.Fatalf("error retrieving customer custom attribute definition: %v", err)
+	}
+
+	d := resourceCustomerCustomAttributeDefinition().Data(nil)
+	if err := customAttributeDefinitionObjectToResource(definition, d); err != nil {
+		t.Fatalf("error reading customer custom attribute definition: %v", err)
+	}
+
+	goldID := d.Get("option_ids").(map[string]interface{})["Gold"].(string)
+
+	state, diags := testApply(t, r, meta, nil, map[string]interface{}{
+		"customer_id": customerID,
+		"key":         "selection-tier",
+		"value":       `["` + goldID + `"]`,
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating customer custom attribute: %v", diags)
+	}
+
+	if err := meta.api.DeleteCustomerCustomAttributeDefinition(context.Background(), "selection-tier"); err != nil {
+		t.Fatalf("error deleting customer custom attribute definition: %v", err)
+	}
+
+	state, diags = r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing customer custom attribute: %v", diags)
+	}
+
+	if state != nil && state.ID != "" {
+		t.Fatalf("expected custom attribute of a deleted definition to be removed from state, found %v", state)
+	}
+}
+
+func TestCustomerCustomAttributeValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value string
+		valid bool
+	}{
+		"string":   {value: `"Gold"`, valid: true},
+		"boolean":  {value: `true`, valid: true},
+		"object":   {value: `{"locality": "London"}`, valid: true},
+		"not json": {value: `Gold`},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]interface{}{
+				"customer_id": "customer",
+				"key":         "loyalty-tier",
+				"value":       test.value,
+			}
+
+			if diags := resourceCustomerCustomAttribute().Validate(terraform.NewResourceConfigRaw(config)); diags.HasError() == test.valid {
+				t.Fatalf("expected valid to be %t, found %v", test.valid, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCustomerGroup() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		CreateContext: resourceCustomerGroupCreate,
+		ReadContext:   resourceCustomerGroupRead,
+		UpdateContext: resourceCustomerGroupUpdate,
+		DeleteContext: resourceCustomerGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceCustomerGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	idempotencyKey, err := uuid.NewV4()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating idempotency key: %w", err))
+	}
+
+	group, err := meta.api.CreateCustomerGroup(ctx, idempotencyKey.String(), &squareapi.CustomerGroup{Name: d.Get("name").(string)})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to create customer group: %w", err))
+	}
+
+	if err := customerGroupObjectToResource(group, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	group, err := meta.api.RetrieveCustomerGroup(ctx, d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] customer group %s not found, removing from state", d.Id())
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(fmt.Errorf("error making network call to retrieve customer group: %w", err))
+	}
+
+	if err := customerGroupObjectToResource(group, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	group, err := meta.api.UpdateCustomerGroup(ctx, d.Id(), &squareapi.CustomerGroup{Name: d.Get("name").(string)})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to update customer group: %w", err))
+	}
+
+	if err := customerGroupObjectToResource(group, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	if err := meta.api.DeleteCustomerGroup(ctx, d.Id()); err != nil && !isNotFound(err) {
+		return diag.FromErr(fmt.Errorf("error making network call to delete customer group: %w", err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func customerGroupObjectToResource(g *squareapi.CustomerGroup, d *schema.ResourceData) error {
+	d.SetId(g.ID)
+
+	if err := d.Set("name", g.Name); err != nil {
+		return fmt.Errorf("error setting name: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// customerGroupMembershipIDSeparator separates the customer and group ids in a membership's id.
+const customerGroupMembershipIDSeparator = ":"
+
+func resourceCustomerGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"customer_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+		CreateContext: resourceCustomerGroupMembershipCreate,
+		ReadContext:   resourceCustomerGroupMembershipRead,
+		DeleteContext: resourceCustomerGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCustomerGroupMembershipImport,
+		},
+	}
+}
+
+func customerGroupMembershipID(customerID, groupID string) string {
+	return customerID + customerGroupMembershipIDSeparator + groupID
+}
+
+func resourceCustomerGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	customerID, groupID := d.Get("customer_id").(string), d.Get("group_id").(string)
+
+	if err := meta.api.AddGroupToCustomer(ctx, customerID, groupID); err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to add customer to group: %w", err))
+	}
+
+	d.SetId(customerGroupMembershipID(customerID, groupID))
+
+	return nil
+}
+
+// resourceCustomerGroupMembershipRead removes the membership from state if the customer has left the group,
+// including when the customer or group has been deleted out of band.
+func resourceCustomerGroupMembershipRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	customerID, groupID := d.Get("customer_id").(string), d.Get("group_id").(string)
+
+	customer, err := meta.api.RetrieveCustomer(ctx, customerID)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] customer %s not found, removing group membership %s from state", customerID, d.Id())
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(fmt.Errorf("error making network call to retrieve customer: %w", err))
+	}
+
+	for _, id := range customer.GroupIDs {
+		if id == groupID {
+			return nil
+		}
+	}
+
+	log.Printf("[WARN] customer %s is no longer in group %s, removing group membership from state", customerID, groupID)
+	d.SetId("")
+
+	return nil
+}
+
+func resourceCustomerGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	err := meta.api.RemoveGroupFromCustomer(ctx, d.Get("customer_id").(string), d.Get("group_id").(string))
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(fmt.Errorf("error making network call to remove customer from group: %w", err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceCustomerGroupMembershipImport imports a membership by an id of the form customer_id:group_id.
+func resourceCustomerGroupMembershipImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), customerGroupMembershipIDSeparator)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid customer group membership id %q, expected customer_id%sgroup_id", d.Id(), customerGroupMembershipIDSeparator)
+	}
+
+	if err := d.Set("customer_id", parts[0]); err != nil {
+		return nil, fmt.Errorf("error setting customer id: %w", err)
+	}
+
+	if err := d.Set("group_id", parts[1]); err != nil {
+		return nil, fmt.Errorf("error setting group id: %w", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
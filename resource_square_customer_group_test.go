@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCustomerGroup(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCustomerGroup()
+
+	state, diags := testApply(t, r, meta, nil, map[string]interface{}{"name": "Regulars"})
+	if diags.HasError() {
+		t.Fatalf("error creating customer group: %v", diags)
+	}
+
+	state, diags = testApply(t, r, meta, state, map[string]interface{}{"name": "Loyal Regulars"})
+	if diags.HasError() {
+		t.Fatalf("error updating customer group: %v", diags)
+	}
+
+	group, err := meta.api.RetrieveCustomerGroup(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving customer group: %v", err)
+	}
+
+	if group.Name != "Loyal Regulars" {
+		t.Fatalf("expected customer group to be renamed, found %+v", group)
+	}
+
+	if diags := r.DeleteContext(context.Background(), r.Data(state), meta); diags.HasError() {
+		t.Fatalf("error deleting customer group: %v", diags)
+	}
+
+	state, diags = r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing customer group: %v", diags)
+	}
+
+	if state != nil && state.ID != "" {
+		t.Fatalf("expected deleted customer group to be removed from state, found %v", state)
+	}
+}
+
+// testCustomerGroupMembership creates a customer, a group, and a membership of one in the other.
+func testCustomerGroupMembership(t *testing.T, meta *providerMeta) (customerID, groupID string, state *terraform.InstanceState) {
+	t.Helper()
+
+	customer, err := meta.api.CreateCustomer(context.Background(), "", &squareapi.Customer{GivenName: "Grace"})
+	if err != nil {
+		t.Fatalf("error creating customer: %v", err)
+	}
+
+	group, err := meta.api.CreateCustomerGroup(context.Background(), "", &squareapi.CustomerGroup{Name: "Regulars"})
+	if err != nil {
+		t.Fatalf("error creating customer group: %v", err)
+	}
+
+	state, diags := testApply(t, resourceCustomerGroupMembership(), meta, nil, map[string]interface{}{
+		"customer_id": customer.ID,
+		"group_id":    group.ID,
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating customer group membership: %v", diags)
+	}
+
+	return customer.ID, group.ID, state
+}
+
+func TestCustomerGroupMembership(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCustomerGroupMembership()
+
+	customerID, groupID, state := testCustomerGroupMembership(t, meta)
+
+	if state.ID != customerID+":"+groupID {
+		t.Fatalf("unexpected membership id %s", state.ID)
+	}
+
+	customer, err := meta.api.RetrieveCustomer(context.Background(), customerID)
+	if err != nil {
+		t.Fatalf("error retrieving customer: %v", err)
+	}
+
+	if len(customer.GroupIDs) != 1 || customer.GroupIDs[0] != groupID {
+		t.Fatalf("expected customer to be in group %s, found %v", groupID, customer.GroupIDs)
+	}
+
+	refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing customer group membership: %v", diags)
+	}
+
+	if refreshed == nil || refreshed.ID != state.ID {
+		t.Fatalf("expected membership to remain in state, found %v", refreshed)
+	}
+
+	if diags := r.DeleteContext(context.Background(), r.Data(state), meta); diags.HasError() {
+		t.Fatalf("error deleting customer group membership: %v", diags)
+	}
+
+	customer, err = meta.api.RetrieveCustomer(context.Background(), customerID)
+	if err != nil {
+		t.Fatalf("error retrieving customer: %v", err)
+	}
+
+	if len(customer.GroupIDs) != 0 {
+		t.Fatalf("expected customer to have left the group, found %v", customer.GroupIDs)
+	}
+}
+
+func TestCustomerGroupMembershipRemovedOutOfBand(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]func(meta *providerMeta, customerID, groupID string) error{
+		"customer deleted": func(meta *providerMeta, customerID, groupID string) error {
+			return meta.api.DeleteCustomer(context.Background(), customerID)
+		},
+		"group deleted": func(meta *providerMeta, customerID, groupID string) error {
+			return meta.api.DeleteCustomerGroup(context.Background(), groupID)
+		},
+		"customer removed from group": func(meta *providerMeta, customerID, groupID string) error {
+			return meta.api.RemoveGroupFromCustomer(context.Background(), customerID, groupID)
+		},
+	}
+
+	for name, remove := range tests {
+		remove := remove
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			meta := testProviderMeta(t, nil)
+			r := resourceCustomerGroupMembership()
+
+			customerID, groupID, state := testCustomerGroupMembership(t, meta)
+
+			if err := remove(meta, customerID, groupID); err != nil {
+				t.Fatalf("error removing membership out of band: %v", err)
+			}
+
+			refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+			if diags.HasError() {
+				t.Fatalf("error refreshing customer group membership: %v", diags)
+			}
+
+			if refreshed != nil && refreshed.ID != "" {
+				t.Fatalf("expected membership to be removed from state, found %v", refreshed)
+			}
+
+			if diags := r.DeleteContext(context.Background(), r.Data(state), meta); diags.HasError() {
+				t.Fatalf("error deleting removed customer group membership: %v", diags)
+			}
+		})
+	}
+}
+
+func TestCustomerGroupMembershipImport(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		id    string
+		valid bool
+	}{
+		"valid":          {id: "customer:group", valid: true},
+		"missing group":  {id: "customer:"},
+		"no separator":   {id: "customer"},
+		"too many parts": {id: "customer:group:extra"},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := resourceCustomerGroupMembership()
+			d := r.Data(nil)
+			d.SetId(test.id)
+
+			imported, err := r.Importer.StateContext(context.Background(), d, nil)
+			if (err == nil) != test.valid {
+				t.Fatalf("expected valid to be %t, found %v", test.valid, err)
+			}
+
+			if test.valid && (imported[0].Get("customer_id") != "customer" || imported[0].Get("group_id") != "group") {
+				t.Fatalf("unexpected imported membership %v", imported[0].State())
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCustomer(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCustomer()
+
+	config := map[string]interface{}{
+		"given_name":    "Ada",
+		"family_name":   "Lovelace",
+		"company_name":  "Analytical Engines",
+		"email_address": "ada@example.com",
+		"phone_number":  "+15555550100",
+		"birthday":      "12-10",
+		"note":          "corporate account",
+		"reference_id":  "customer-ada",
+		"address": []interface{}{
+			map[string]interface{}{
+				"address_line_1": "12 St James's Square",
+				"locality":       "London",
+				"country":        "GB",
+			},
+		},
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating customer: %v", diags)
+	}
+
+	customer, err := meta.api.RetrieveCustomer(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving customer: %v", err)
+	}
+
+	if customer.Birthday != "0000-12-10" || customer.Address == nil || customer.Address.Locality != "London" || customer.ReferenceID != "customer-ada" {
+		t.Fatalf("unexpected customer %+v", customer)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning customer: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+
+	delete(config, "note")
+	delete(config, "address")
+	delete(config, "birthday")
+	config["given_name"] = "Augusta"
+
+	state, diags = testApply(t, r, meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("error updating customer: %v", diags)
+	}
+
+	customer, err = meta.api.RetrieveCustomer(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving customer: %v", err)
+	}
+
+	if customer.GivenName != "Augusta" || customer.Note != "" || customer.Address != nil || customer.Birthday != "" {
+		t.Fatalf("expected removed fields to be cleared, found %+v", customer)
+	}
+
+	if diags := r.DeleteContext(context.Background(), r.Data(state), meta); diags.HasError() {
+		t.Fatalf("error deleting customer: %v", diags)
+	}
+
+	state, diags = r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing customer: %v", diags)
+	}
+
+	if state != nil && state.ID != "" {
+		t.Fatalf("expected deleted customer to be removed from state, found %v", state)
+	}
+}
+
+func TestCustomerValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"email only":           {config: map[string]interface{}{"email_address": "someone@example.com"}, valid: true},
+		"birthday with year":   {config: map[string]interface{}{"given_name": "someone", "birthday": "1990-02-28"}, valid: true},
+		"no identifying field": {config: map[string]interface{}{"note": "nobody"}},
+		"invalid birthday":     {config: map[string]interface{}{"given_name": "someone", "birthday": "02/28/1990"}},
+		"invalid month":        {config: map[string]interface{}{"given_name": "someone", "birthday": "13-01"}},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diags := resourceCustomer().Validate(terraform.NewResourceConfigRaw(test.config)); diags.HasError() == test.valid {
+				t.Fatalf("expected valid to be %t, found %v", test.valid, diags)
+			}
+		})
+	}
+}
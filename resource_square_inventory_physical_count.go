@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"regexp"
+	"time"
+
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// inventoryQuantityRegexp matches the decimal quantities square accepts, with up to 5 decimal places.
+var inventoryQuantityRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,5})?$`)
+
+// inventoryStateInStock is the state physical counts are made in.
+const inventoryStateInStock = "IN_STOCK"
+
+func resourceInventoryPhysicalCount() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"catalog_object_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"location_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Square's inventory history can't be changed, so a new quantity is recorded as a new count.
+			"quantity": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(inventoryQuantityRegexp, "must be a non-negative decimal with at most 5 decimal places")),
+				DiffSuppressFunc: suppressInventoryQuantityDiff,
+			},
+			"occurred_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		CreateContext: resourceInventoryPhysicalCountCreate,
+		ReadContext:   resourceInventoryPhysicalCountRead,
+		DeleteContext: resourceInventoryPhysicalCountDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceInventoryPhysicalCountImport,
+		},
+	}
+}
+
+// suppressInventoryQuantityDiff ignores differences in how a quantity is written, such as 10 and 10.0.
+func suppressInventoryQuantityDiff(k, o, n string, d *schema.ResourceData) bool {
+	oldQuantity, ok := new(big.Rat).SetString(o)
+	if !ok {
+		return false
+	}
+
+	newQuantity, ok := new(big.Rat).SetString(n)
+	if !ok {
+		return false
+	}
+
+	return oldQuantity.Cmp(newQuantity) == 0
+}
+
+func resourceInventoryPhysicalCountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	idempotencyKey, err := uuid.NewV4()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating idempotency key: %w", err))
+	}
+
+	changes, err := meta.api.BatchChangeInventory(ctx, idempotencyKey.String(), []*squareapi.InventoryChange{{
+		Type: squareapi.InventoryChangeTypePhysicalCount,
+		PhysicalCount: &squareapi.InventoryPhysicalCount{
+			CatalogObjectID: d.Get("catalog_object_id").(string),
+			LocationID:      d.Get("location_id").(string),
+			State:           inventoryStateInStock,
+			Quantity:        d.Get("quantity").(string),
+			OccurredAt:      time.Now().UTC().Format(time.RFC3339),
+		},
+	}})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to change inventory: %w", err))
+	}
+
+	if changes[0].PhysicalCount == nil {
+		return diag.Errorf("square returned a %s change for a physical count", changes[0].Type)
+	}
+
+	if err := inventoryPhysicalCountObjectToResource(changes[0].PhysicalCount, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceInventoryPhysicalCountRead reads the current in stock quantity of the counted object, rather than the
+// count itself, so that sales and other changes made since the count show up as drift.  The count is removed
+// from state if the counted object has been deleted.
+func resourceInventoryPhysicalCountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	catalogObjectID, locationID := d.Get("catalog_object_id").(string), d.Get("location_id").(string)
+
+	object, err := meta.cache.retrieve(ctx, catalogObjectID)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] catalog object %s not found, removing inventory physical count %s from state", catalogObjectID, d.Id())
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(fmt.Errorf("error making network call to retrieve object: %w", err))
+	}
+
+	if object == nil || object.IsDeleted {
+		log.Printf("[WARN] catalog object %s has been deleted, removing inventory physical count %s from state", catalogObjectID, d.Id())
+		d.SetId("")
+
+		return nil
+	}
+
+	counts, err := meta.api.BatchRetrieveInventoryCounts(ctx, &squareapi.InventoryCountFilter{
+		CatalogObjectIDs: []string{catalogObjectID},
+		LocationIDs:      []string{locationID},
+		States:           []string{inventoryStateInStock},
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to retrieve inventory counts: %w", err))
+	}
+
+	quantity := "0"
+
+	for _, c := range counts {
+		if c.CatalogObjectID == catalogObjectID && c.LocationID == locationID && c.State == inventoryStateInStock {
+			quantity = c.Quantity
+		}
+	}
+
+	if err := d.Set("quantity", quantity); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting quantity: %w", err))
+	}
+
+	return nil
+}
+
+// resourceInventoryPhysicalCountImport imports a physical count by its id, reading the counted object and location
+// from the count.
+func resourceInventoryPhysicalCountImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return nil, fmt.Errorf("unable to create client from interface")
+	}
+
+	count, err := meta.api.RetrieveInventoryPhysicalCount(ctx, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("error making network call to retrieve inventory physical count: %w", err)
+	}
+
+	if err := inventoryPhysicalCountObjectToResource(count, d); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceInventoryPhysicalCountDelete only removes the count from state, as square doesn't allow inventory
+// changes to be undone.  The counted quantity stays in place until the next count or sale.
+func resourceInventoryPhysicalCountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Inventory physical count removed from state only",
+		Detail:   "Square doesn't allow inventory changes to be undone, so the counted quantity stays in place until the next count or sale.",
+	}}
+}
+
+func inventoryPhysicalCountObjectToResource(count *squareapi.InventoryPhysicalCount, d *schema.ResourceData) error {
+	d.SetId(count.ID)
+
+	for k, v := range map[string]interface{}{
+		"catalog_object_id": count.CatalogObjectID,
+		"location_id":       count.LocationID,
+		"quantity":          count.Quantity,
+		"occurred_at":       count.OccurredAt,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("error setting %s: %w", k, err)
+		}
+	}
+
+	return nil
+}
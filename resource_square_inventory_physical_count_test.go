@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testInventoryVariation creates an item with a single variation and a location to count it at.
+func testInventoryVariation(t *testing.T, meta *providerMeta, name string) (variationID, locationID string) {
+	t.Helper()
+
+	item, diags := testApply(t, resourceCatalogItem(), meta, nil, map[string]interface{}{
+		"name": name,
+		"variation": []interface{}{
+			map[string]interface{}{
+				"name":            name + "-variation",
+				"pricing_type":    "VARIABLE_PRICING",
+				"track_inventory": true,
+			},
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating item: %v", diags)
+	}
+
+	res, err := meta.api.RetrieveCatalogObject(context.Background(), item.ID)
+	if err != nil {
+		t.Fatalf("error retrieving item: %v", err)
+	}
+
+	location, err := meta.api.CreateLocation(context.Background(), &squareapi.Location{Name: name + "-location"})
+	if err != nil {
+		t.Fatalf("error creating location: %v", err)
+	}
+
+	return res.Type.(*objects.CatalogItem).Variations[0].ID, location.ID
+}
+
+func TestInventoryPhysicalCount(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceInventoryPhysicalCount()
+
+	variationID, locationID := testInventoryVariation(t, meta, "physical-count-item")
+
+	config := map[string]interface{}{
+		"catalog_object_id": variationID,
+		"location_id":       locationID,
+		"quantity":          "12",
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating physical count: %v", diags)
+	}
+
+	count, err := meta.api.RetrieveInventoryPhysicalCount(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving physical count: %v", err)
+	}
+
+	if count.Quantity != "12" || count.State != inventoryStateInStock || count.OccurredAt == "" {
+		t.Fatalf("unexpected physical count %+v", count)
+	}
+
+	config["quantity"] = "12.000"
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning physical count: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected equal quantities not to produce a diff, found %v", diff)
+	}
+
+	firstID := state.ID
+	config["quantity"] = "7.5"
+
+	// Changing the quantity replaces the count with a new one.
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning physical count: %v", err)
+	}
+
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatalf("expected a new quantity to replace the count, found %v", diff)
+	}
+
+	state, diags = testApply(t, r, meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("error replacing physical count: %v", diags)
+	}
+
+	if state.ID == firstID {
+		t.Fatal("expected a new physical count to be recorded")
+	}
+
+	if count, err := meta.api.RetrieveInventoryPhysicalCount(context.Background(), state.ID); err != nil || count.Quantity != "7.5" {
+		t.Fatalf("expected the resource id to be that of the new count, found %+v, %v", count, err)
+	}
+
+	counts, err := meta.api.BatchRetrieveInventoryCounts(context.Background(), &squareapi.InventoryCountFilter{
+		CatalogObjectIDs: []string{variationID},
+		LocationIDs:      []string{locationID},
+	})
+	if err != nil {
+		t.Fatalf("error retrieving inventory counts: %v", err)
+	}
+
+	if len(counts) != 1 || counts[0].Quantity != "7.5" {
+		t.Fatalf("expected the latest count to set the quantity, found %+v", counts)
+	}
+
+	refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing physical count: %v", diags)
+	}
+
+	if refreshed == nil || refreshed.Attributes["quantity"] != "7.5" {
+		t.Fatalf("unexpected refreshed physical count %v", refreshed)
+	}
+
+	// A count recorded outside of terraform shows up as drift.
+	if _, err := meta.api.BatchChangeInventory(context.Background(), t.Name(), []*squareapi.InventoryChange{{
+		Type: squareapi.InventoryChangeTypePhysicalCount,
+		PhysicalCount: &squareapi.InventoryPhysicalCount{
+			CatalogObjectID: variationID,
+			LocationID:      locationID,
+			State:           inventoryStateInStock,
+			Quantity:        "3",
+			OccurredAt:      time.Now().UTC().Format(time.RFC3339),
+		},
+	}}); err != nil {
+		t.Fatalf("error counting inventory out of band: %v", err)
+	}
+
+	refreshed, diags = r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing physical count: %v", diags)
+	}
+
+	if refreshed == nil || refreshed.Attributes["quantity"] != "3" || refreshed.ID != state.ID {
+		t.Fatalf("expected refresh to read the current quantity, found %v", refreshed)
+	}
+
+	imported, err := r.Importer.StateContext(context.Background(), r.Data(&terraform.InstanceState{ID: state.ID}), meta)
+	if err != nil {
+		t.Fatalf("error importing physical count: %v", err)
+	}
+
+	if imported[0].Get("catalog_object_id") != variationID || imported[0].Get("location_id") != locationID {
+		t.Fatalf("unexpected imported physical count %v", imported[0].State())
+	}
+
+	diags = r.DeleteContext(context.Background(), r.Data(state), meta)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected deleting physical count to warn, found %v", diags)
+	}
+}
+
+func TestInventoryPhysicalCountDeletedObject(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceInventoryPhysicalCount()
+
+	variationID, locationID := testInventoryVariation(t, meta, "physical-count-deleted-item")
+
+	state, diags := testApply(t, r, meta, nil, map[string]interface{}{
+		"catalog_object_id": variationID,
+		"location_id":       locationID,
+		"quantity":          "4",
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating physical count: %v", diags)
+	}
+
+	if _, err := meta.api.DeleteCatalogObject(context.Background(), variationID); err != nil {
+		t.Fatalf("error deleting variation: %v", err)
+	}
+
+	refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing physical count: %v", diags)
+	}
+
+	if refreshed != nil && refreshed.ID != "" {
+		t.Fatalf("expected physical count of deleted variation to be removed from state, found %v", refreshed)
+	}
+}
+
+func TestInventoryPhysicalCountValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		quantity string
+		valid    bool
+	}{
+		"whole":          {quantity: "10", valid: true},
+		"decimal":        {quantity: "2.12345", valid: true},
+		"zero":           {quantity: "0", valid: true},
+		"negative":       {quantity: "-1"},
+		"too precise":    {quantity: "1.123456"},
+		"not a quantity": {quantity: "ten"},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]interface{}{
+				"catalog_object_id": "variation",
+				"location_id":       "location",
+				"quantity":          test.quantity,
+			}
+
+			if diags := resourceInventoryPhysicalCount().Validate(terraform.NewResourceConfigRaw(config)); diags.HasError() == test.valid {
+				t.Fatalf("expected valid to be %t, found %v", test.valid, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	locationStatusActive   = "ACTIVE"
+	locationStatusInactive = "INACTIVE"
+)
+
+var daysOfWeek = []string{"MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"}
+
+// localTimeRegexp requires seconds, as square always returns them and a time without them would drift.
+var localTimeRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]$`)
+
+var addressSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"address_line_1": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"address_line_2": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"address_line_3": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"locality": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"sublocality": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"administrative_district_level_1": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"postal_code": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"country": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	},
+}
+
+var businessHoursPeriodSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"day_of_week": &schema.Schema{
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(daysOfWeek, false)),
+		},
+		"start_local_time": &schema.Schema{
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(localTimeRegexp, "expected a local time in the format HH:MM:SS")),
+		},
+		"end_local_time": &schema.Schema{
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(localTimeRegexp, "expected a local time in the format HH:MM:SS")),
+		},
+	},
+}
+
+var coordinatesSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"latitude": &schema.Schema{
+			Type:             schema.TypeFloat,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.FloatBetween(-90, 90)), //nolint:gomnd
+		},
+		"longitude": &schema.Schema{
+			Type:             schema.TypeFloat,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.FloatBetween(-180, 180)), //nolint:gomnd
+		},
+	},
+}
+
+func locationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"address": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem:     addressSchema,
+		},
+		"timezone": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"business_hours": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     businessHoursPeriodSchema,
+		},
+		"status": &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          locationStatusActive,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{locationStatusActive, locationStatusInactive}, false)),
+		},
+		"business_email": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"description": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"coordinates": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem:     coordinatesSchema,
+		},
+	}
+}
+
+func resourceLocation() *schema.Resource {
+	return &schema.Resource{
+		Schema:        locationSchema(),
+		CreateContext: resourceLocationCreate,
+		ReadContext:   resourceLocationRead,
+		UpdateContext: resourceLocationUpdate,
+		DeleteContext: resourceLocationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceLocationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	location, err := meta.api.CreateLocation(ctx, locationResourceToObject(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to create location: %w", err))
+	}
+
+	if err := locationObjectToResource(location, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceLocationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	location, err := meta.api.RetrieveLocation(ctx, d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] location %s not found, removing from state", d.Id())
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(fmt.Errorf("error making network call to retrieve location: %w", err))
+	}
+
+	if err := locationObjectToResource(location, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// locationClearableFields are the optional attributes that must be cleared explicitly when they're removed,
+// as location updates leave missing fields untouched.
+var locationClearableFields = []string{"address", "business_hours", "business_email", "description", "coordinates"}
+
+func resourceLocationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	clear := []string{}
+
+	for _, field := range locationClearableFields {
+		if _, n := d.GetChange(field); d.HasChange(field) && isEmptyValue(n) {
+			clear = append(clear, field)
+		}
+	}
+
+	location, err := meta.api.UpdateLocation(ctx, d.Id(), locationResourceToObject(d), clear)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to update location: %w", err))
+	}
+
+	if err := locationObjectToResource(location, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceLocationDelete deactivates the location, as square doesn't allow locations to be deleted.
+func resourceLocationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	_, err := meta.api.UpdateLocation(ctx, d.Id(), &squareapi.Location{Status: locationStatusInactive}, nil)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(fmt.Errorf("error making network call to deactivate location: %w", err))
+	}
+
+	d.SetId("")
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Location deactivated",
+		Detail:   "Square doesn't allow locations to be deleted, so the location has been marked inactive instead.",
+	}}
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch t := v.(type) {
+	case string:
+		return t == ""
+	case []interface{}:
+		return len(t) == 0
+	case nil:
+		return true
+	}
+
+	return false
+}
+
+func locationResourceToObject(d *schema.ResourceData) *squareapi.Location {
+	location := &squareapi.Location{
+		Name:          d.Get("name").(string),
+		Timezone:      d.Get("timezone").(string),
+		Status:        d.Get("status").(string),
+		BusinessEmail: d.Get("business_email").(string),
+		Description:   d.Get("description").(string),
+	}
+
+	if address := d.Get("address").([]interface{}); len(address) > 0 && address[0] != nil {
+		location.Address = addressResourceToObject(address[0].(map[string]interface{}))
+	}
+
+	if periods := d.Get("business_hours").([]interface{}); len(periods) > 0 {
+		location.BusinessHours = &squareapi.BusinessHours{
+			Periods: make([]*squareapi.BusinessHoursPeriod, len(periods)),
+		}
+
+		for i, p := range periods {
+			mp := p.(map[string]interface{})
+
+			location.BusinessHours.Periods[i] = &squareapi.BusinessHoursPeriod{
+				DayOfWeek:      mp["day_of_week"].(string),
+				StartLocalTime: mp["start_local_time"].(string),
+				EndLocalTime:   mp["end_local_time"].(string),
+			}
+		}
+	}
+
+	if coordinates := d.Get("coordinates").([]interface{}); len(coordinates) > 0 && coordinates[0] != nil {
+		mc := coordinates[0].(map[string]interface{})
+
+		location.Coordinates = &objects.Coordinates{
+			Latitude:  mc["latitude"].(float64),
+			Longitude: mc["longitude"].(float64),
+		}
+	}
+
+	return location
+}
+
+func addressResourceToObject(ma map[string]interface{}) *objects.Address {
+	return &objects.Address{
+		AddressLine1:                 ma["address_line_1"].(string),
+		AddressLine2:                 ma["address_line_2"].(string),
+		AddressLine3:                 ma["address_line_3"].(string),
+		Locality:                     ma["locality"].(string),
+		Sublocality:                  ma["sublocality"].(string),
+		AdministrativeDistrictLevel1: ma["administrative_district_level_1"].(string),
+		PostalCode:                   ma["postal_code"].(string),
+		Country:                      objects.Country(ma["country"].(string)),
+	}
+}
+
+func addressObjectToResource(a *objects.Address) []interface{} {
+	if a == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"address_line_1":                  a.AddressLine1,
+			"address_line_2":                  a.AddressLine2,
+			"address_line_3":                  a.AddressLine3,
+			"locality":                        a.Locality,
+			"sublocality":                     a.Sublocality,
+			"administrative_district_level_1": a.AdministrativeDistrictLevel1,
+			"postal_code":                     a.PostalCode,
+			"country":                         string(a.Country),
+		},
+	}
+}
+
+// locationToMap converts a location into its terraform representation, keyed by attribute name.
+func locationToMap(l *squareapi.Location) map[string]interface{} {
+	periods := []interface{}{}
+
+	if l.BusinessHours != nil {
+		for _, p := range l.BusinessHours.Periods {
+			periods = append(periods, map[string]interface{}{
+				"day_of_week":      p.DayOfWeek,
+				"start_local_time": p.StartLocalTime,
+				"end_local_time":   p.EndLocalTime,
+			})
+		}
+	}
+
+	coordinates := []interface{}{}
+	if l.Coordinates != nil {
+		coordinates = append(coordinates, map[string]interface{}{
+			"latitude":  l.Coordinates.Latitude,
+			"longitude": l.Coordinates.Longitude,
+		})
+	}
+
+	return map[string]interface{}{
+		"id":             l.ID,
+		"name":           l.Name,
+		"address":        addressObjectToResource(l.Address),
+		"timezone":       l.Timezone,
+		"business_hours": periods,
+		"status":         l.Status,
+		"business_email": l.BusinessEmail,
+		"description":    l.Description,
+		"coordinates":    coordinates,
+	}
+}
+
+func locationObjectToResource(l *squareapi.Location, d *schema.ResourceData) error {
+	d.SetId(l.ID)
+
+	for k, v := range locationToMap(l) {
+		if k == "id" {
+			continue
+		}
+
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("error setting %s: %w", k, err)
+		}
+	}
+
+	return nil
+}
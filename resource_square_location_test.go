@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const locationBlock1 = `resource "square_location" "test_location" {
+	name = "my-location"
+	timezone = "America/Chicago"
+	business_email = "store@example.com"
+	description = "my store"
+
+	address {
+		address_line_1 = "123 Main St"
+		locality = "Chicago"
+		administrative_district_level_1 = "IL"
+		postal_code = "60601"
+		country = "US"
+	}
+
+	business_hours {
+		day_of_week = "MON"
+		start_local_time = "09:00:00"
+		end_local_time = "17:00:00"
+	}
+
+	business_hours {
+		day_of_week = "TUE"
+		start_local_time = "09:00:00"
+		end_local_time = "17:00:00"
+	}
+
+	coordinates {
+		latitude = 41.8781
+		longitude = -87.6298
+	}
+}
+
+`
+
+const locationBlock2 = `resource "square_location" "test_location" {
+	name = "my-location-renamed"
+	timezone = "America/Chicago"
+}
+
+`
+
+func TestLocation(t *testing.T) {
+	t.Parallel()
+
+	token := testAccToken()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"square": func() (*schema.Provider, error) { return Provider(), nil }, //nolint:unparam
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerBlock(token) + locationBlock1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_location.test_location", "name", "my-location"),
+					resource.TestCheckResourceAttr("square_location.test_location", "status", "ACTIVE"),
+					resource.TestCheckResourceAttr("square_location.test_location", "address.0.locality", "Chicago"),
+					resource.TestCheckResourceAttr("square_location.test_location", "business_hours.#", "2"),
+					resource.TestCheckResourceAttr("square_location.test_location", "business_hours.1.day_of_week", "TUE"),
+					resource.TestCheckResourceAttr("square_location.test_location", "coordinates.0.latitude", "41.8781"),
+					checkLocationRemote("square_location.test_location", token),
+				),
+			},
+			{
+				ResourceName:      "square_location.test_location",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: providerBlock(token) + locationBlock2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_location.test_location", "name", "my-location-renamed"),
+					resource.TestCheckResourceAttr("square_location.test_location", "description", ""),
+					resource.TestCheckResourceAttr("square_location.test_location", "address.#", "0"),
+					resource.TestCheckResourceAttr("square_location.test_location", "business_hours.#", "0"),
+					checkLocationRemote("square_location.test_location", token),
+				),
+			},
+		},
+	})
+}
+
+func checkLocationRemote(resourceName, apiKey string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		client, err := testSquareAPIClient(apiKey)
+		if err != nil {
+			return err
+		}
+
+		location, err := client.RetrieveLocation(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving remote location: %w", err)
+		}
+
+		if location.Name != rs.Primary.Attributes["name"] {
+			return fmt.Errorf("unexpected name")
+		}
+
+		if location.Description != rs.Primary.Attributes["description"] {
+			return fmt.Errorf("unexpected description")
+		}
+
+		if location.BusinessEmail != rs.Primary.Attributes["business_email"] {
+			return fmt.Errorf("unexpected business email")
+		}
+
+		if (location.Address != nil) != (rs.Primary.Attributes["address.#"] == "1") {
+			return fmt.Errorf("unexpected address")
+		}
+
+		return nil
+	}
+}
+
+func TestLocationLifecycle(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceLocation()
+
+	state, diags := testApply(t, r, meta, nil, map[string]interface{}{
+		"name":        "lifecycle-location",
+		"description": "my store",
+		"business_hours": []interface{}{
+			map[string]interface{}{
+				"day_of_week":      "MON",
+				"start_local_time": "09:00:00",
+				"end_local_time":   "17:00:00",
+			},
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("error creating location: %v", diags)
+	}
+
+	if state.Attributes["timezone"] == "" {
+		t.Fatalf("expected timezone to be read back from square")
+	}
+
+	state, diags = testApply(t, r, meta, state, map[string]interface{}{
+		"name": "lifecycle-location",
+	})
+	if diags.HasError() {
+		t.Fatalf("error updating location: %v", diags)
+	}
+
+	location, err := meta.api.RetrieveLocation(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving location: %v", err)
+	}
+
+	if location.Description != "" || location.BusinessHours != nil {
+		t.Fatalf("expected removed attributes to be cleared remotely")
+	}
+
+	if _, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, meta); diags.HasError() {
+		t.Fatalf("error deleting location: %v", diags)
+	}
+
+	location, err = meta.api.RetrieveLocation(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving location: %v", err)
+	}
+
+	if location.Status != locationStatusInactive {
+		t.Fatalf("expected deleted location to be inactive, found %s", location.Status)
+	}
+}
+
+func TestLocationBusinessHoursValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		localTime string
+		valid     bool
+	}{
+		"with seconds":    {localTime: "09:00:00", valid: true},
+		"without seconds": {localTime: "09:00"},
+		"out of range":    {localTime: "24:00:00"},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]interface{}{
+				"name": "validate-location",
+				"business_hours": []interface{}{
+					map[string]interface{}{
+						"day_of_week":      "MON",
+						"start_local_time": test.localTime,
+						"end_local_time":   "17:00:00",
+					},
+				},
+			}
+
+			if diags := resourceLocation().Validate(terraform.NewResourceConfigRaw(config)); diags.HasError() == test.valid {
+				t.Fatalf("expected valid to be %t, found %v", test.valid, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,421 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	teamMemberStatusActive   = "ACTIVE"
+	teamMemberStatusInactive = "INACTIVE"
+)
+
+const (
+	payTypeHourly = "HOURLY"
+	payTypeSalary = "SALARY"
+	payTypeNone   = "NONE"
+)
+
+var jobAssignmentSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"job_title": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"pay_type": &schema.Schema{
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{payTypeHourly, payTypeSalary, payTypeNone}, false)),
+		},
+		"hourly_rate": &schema.Schema{
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+		},
+		"annual_rate": &schema.Schema{
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+		},
+		"weekly_hours": &schema.Schema{
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+		},
+		"currency": &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validateCurrency,
+		},
+	},
+}
+
+func resourceTeamMember() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"given_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"family_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"email_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"phone_number": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"reference_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          teamMemberStatusActive,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{teamMemberStatusActive, teamMemberStatusInactive}, false)),
+			},
+			"assigned_locations": &schema.Schema{
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"all_locations"},
+			},
+			"all_locations": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"assigned_locations"},
+			},
+			"wage_setting": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"is_overtime_exempt": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"job_assignment": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem:     jobAssignmentSchema,
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: customizeDiffJobAssignments,
+		CreateContext: resourceTeamMemberCreate,
+		ReadContext:   resourceTeamMemberRead,
+		UpdateContext: resourceTeamMemberUpdate,
+		DeleteContext: resourceTeamMemberDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// customizeDiffJobAssignments checks each job sets the rates its pay type calls for, and no others.
+func customizeDiffJobAssignments(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("wage_setting") {
+		return nil
+	}
+
+	for _, j := range d.Get("wage_setting.0.job_assignment").([]interface{}) {
+		mj, ok := j.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		hourly, annual, weekly := mj["hourly_rate"].(int) != 0, mj["annual_rate"].(int) != 0, mj["weekly_hours"].(int) != 0
+
+		switch mj["pay_type"].(string) {
+		case payTypeHourly:
+			if !hourly || annual || weekly {
+				return fmt.Errorf("job assignment %q: a pay_type of %s requires hourly_rate, without annual_rate or weekly_hours", mj["job_title"], payTypeHourly)
+			}
+		case payTypeSalary:
+			if !annual || !weekly || hourly {
+				return fmt.Errorf("job assignment %q: a pay_type of %s requires annual_rate and weekly_hours, without hourly_rate", mj["job_title"], payTypeSalary)
+			}
+		case payTypeNone:
+			if hourly || annual || weekly || mj["currency"].(string) != "" {
+				return fmt.Errorf("job assignment %q: rates can't be set with a pay_type of %s", mj["job_title"], payTypeNone)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceTeamMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	idempotencyKey, err := uuid.NewV4()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating idempotency key: %w", err))
+	}
+
+	teamMember, err := meta.api.CreateTeamMember(ctx, idempotencyKey.String(), teamMemberResourceToObject(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to create team member: %w", err))
+	}
+
+	d.SetId(teamMember.ID)
+
+	var wageSetting *squareapi.WageSetting
+
+	if _, ok := d.GetOk("wage_setting"); ok {
+		wageSetting, err = meta.api.UpdateWageSetting(ctx, teamMember.ID, wageSettingResourceToObject(d, meta))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error making network call to update wage setting: %w", err))
+		}
+	}
+
+	if err := teamMemberObjectToResource(teamMember, wageSetting, d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceTeamMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	teamMember, err := meta.api.RetrieveTeamMember(ctx, d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] team member %s not found, removing from state", d.Id())
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(fmt.Errorf("error making network call to retrieve team member: %w", err))
+	}
+
+	wageSetting, err := meta.api.RetrieveWageSetting(ctx, d.Id())
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(fmt.Errorf("error making network call to retrieve wage setting: %w", err))
+	}
+
+	if err := teamMemberObjectToResource(teamMember, wageSetting, d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// teamMemberClearableFields are the optional attributes that must be cleared explicitly when they're removed,
+// as team member updates leave missing fields untouched.
+var teamMemberClearableFields = []string{"email_address", "phone_number", "reference_id"}
+
+func resourceTeamMemberUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	clear := []string{}
+
+	for _, field := range teamMemberClearableFields {
+		if _, n := d.GetChange(field); d.HasChange(field) && isEmptyValue(n) {
+			clear = append(clear, field)
+		}
+	}
+
+	teamMember, err := meta.api.UpdateTeamMember(ctx, d.Id(), teamMemberResourceToObject(d), clear)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error making network call to update team member: %w", err))
+	}
+
+	var wageSetting *squareapi.WageSetting
+
+	// Removing the wage_setting block removes every job assignment.
+	if d.HasChange("wage_setting") {
+		wageSetting, err = meta.api.UpdateWageSetting(ctx, d.Id(), wageSettingResourceToObject(d, meta))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error making network call to update wage setting: %w", err))
+		}
+	} else {
+		wageSetting, err = meta.api.RetrieveWageSetting(ctx, d.Id())
+		if err != nil && !isNotFound(err) {
+			return diag.FromErr(fmt.Errorf("error making network call to retrieve wage setting: %w", err))
+		}
+	}
+
+	if err := teamMemberObjectToResource(teamMember, wageSetting, d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceTeamMemberDelete deactivates the team member, as square doesn't allow team members to be deleted.
+func resourceTeamMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return diag.Errorf("unable to create client from interface")
+	}
+
+	_, err := meta.api.UpdateTeamMember(ctx, d.Id(), &squareapi.TeamMember{Status: teamMemberStatusInactive}, nil)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(fmt.Errorf("error making network call to deactivate team member: %w", err))
+	}
+
+	d.SetId("")
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Team member deactivated",
+		Detail:   "Square doesn't allow team members to be deleted, so the team member has been marked inactive instead.",
+	}}
+}
+
+func teamMemberResourceToObject(d *schema.ResourceData) *squareapi.TeamMember {
+	assigned := &squareapi.TeamMemberAssignedLocations{
+		AssignmentType: squareapi.TeamMemberAssignmentTypeExplicit,
+		LocationIDs:    stringSet(d.Get("assigned_locations")),
+	}
+
+	if d.Get("all_locations").(bool) {
+		assigned = &squareapi.TeamMemberAssignedLocations{AssignmentType: squareapi.TeamMemberAssignmentTypeAll}
+	}
+
+	return &squareapi.TeamMember{
+		GivenName:         d.Get("given_name").(string),
+		FamilyName:        d.Get("family_name").(string),
+		EmailAddress:      d.Get("email_address").(string),
+		PhoneNumber:       d.Get("phone_number").(string),
+		ReferenceID:       d.Get("reference_id").(string),
+		Status:            d.Get("status").(string),
+		AssignedLocations: assigned,
+	}
+}
+
+func wageSettingResourceToObject(d *schema.ResourceData, meta *providerMeta) *squareapi.WageSetting {
+	wageSetting := &squareapi.WageSetting{
+		JobAssignments: []*squareapi.JobAssignment{},
+	}
+
+	dWageSetting := d.Get("wage_setting").([]interface{})
+	if len(dWageSetting) == 0 || dWageSetting[0] == nil {
+		return wageSetting
+	}
+
+	mWageSetting := dWageSetting[0].(map[string]interface{})
+	wageSetting.IsOvertimeExempt = mWageSetting["is_overtime_exempt"].(bool)
+
+	for _, j := range mWageSetting["job_assignment"].([]interface{}) {
+		mj := j.(map[string]interface{})
+
+		job := &squareapi.JobAssignment{
+			JobTitle:    mj["job_title"].(string),
+			PayType:     mj["pay_type"].(string),
+			WeeklyHours: mj["weekly_hours"].(int),
+		}
+
+		switch job.PayType {
+		case payTypeHourly:
+			job.HourlyRate = &objects.Money{Amount: mj["hourly_rate"].(int), Currency: meta.currency(mj["currency"].(string))}
+		case payTypeSalary:
+			job.AnnualRate = &objects.Money{Amount: mj["annual_rate"].(int), Currency: meta.currency(mj["currency"].(string))}
+		}
+
+		wageSetting.JobAssignments = append(wageSetting.JobAssignments, job)
+	}
+
+	return wageSetting
+}
+
+func teamMemberObjectToResource(teamMember *squareapi.TeamMember, wageSetting *squareapi.WageSetting, d *schema.ResourceData, meta *providerMeta) error {
+	d.SetId(teamMember.ID)
+
+	allLocations := false
+	assignedLocations := []interface{}{}
+
+	if teamMember.AssignedLocations != nil {
+		allLocations = teamMember.AssignedLocations.AssignmentType == squareapi.TeamMemberAssignmentTypeAll
+		assignedLocations = stringsToInterfaces(teamMember.AssignedLocations.LocationIDs)
+	}
+
+	for k, v := range map[string]interface{}{
+		"given_name":         teamMember.GivenName,
+		"family_name":        teamMember.FamilyName,
+		"email_address":      teamMember.EmailAddress,
+		"phone_number":       teamMember.PhoneNumber,
+		"reference_id":       teamMember.ReferenceID,
+		"status":             teamMember.Status,
+		"assigned_locations": assignedLocations,
+		"all_locations":      allLocations,
+		"wage_setting":       wageSettingToList(wageSetting, d, meta),
+	} {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("error setting %s: %w", k, err)
+		}
+	}
+
+	return nil
+}
+
+// wageSettingToList converts a wage setting into its terraform representation.  A wage setting without any
+// jobs is left out, as that's what removing the wage_setting block leaves behind.
+func wageSettingToList(wageSetting *squareapi.WageSetting, d *schema.ResourceData, meta *providerMeta) []interface{} {
+	if wageSetting == nil || len(wageSetting.JobAssignments) == 0 {
+		return []interface{}{}
+	}
+
+	jobs := make([]interface{}, len(wageSetting.JobAssignments))
+
+	for i, job := range wageSetting.JobAssignments {
+		configuredCurrency, _ := d.Get(fmt.Sprintf("wage_setting.0.job_assignment.%d.currency", i)).(string)
+
+		mj := map[string]interface{}{
+			"job_title":    job.JobTitle,
+			"pay_type":     job.PayType,
+			"hourly_rate":  0,
+			"annual_rate":  0,
+			"weekly_hours": job.WeeklyHours,
+			"currency":     "",
+		}
+
+		// Square works out an hourly rate for salaried jobs too, which isn't configurable, so it's only read back
+		// for hourly jobs.
+		if job.HourlyRate != nil && job.PayType == payTypeHourly {
+			mj["hourly_rate"] = job.HourlyRate.Amount
+			mj["currency"] = meta.stateCurrency(job.HourlyRate.Currency, configuredCurrency)
+		}
+
+		if job.AnnualRate != nil {
+			mj["annual_rate"] = job.AnnualRate.Amount
+			mj["currency"] = meta.stateCurrency(job.AnnualRate.Currency, configuredCurrency)
+		}
+
+		jobs[i] = mj
+	}
+
+	return []interface{}{map[string]interface{}{
+		"is_overtime_exempt": wageSetting.IsOvertimeExempt,
+		"job_assignment":     jobs,
+	}}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestTeamMember(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceTeamMember()
+
+	location, err := meta.api.CreateLocation(context.Background(), &squareapi.Location{Name: "team-member-location"})
+	if err != nil {
+		t.Fatalf("error creating location: %v", err)
+	}
+
+	config := map[string]interface{}{
+		"given_name":         "Ada",
+		"family_name":        "Lovelace",
+		"email_address":      "ada@team.example.com",
+		"phone_number":       "+15555550102",
+		"reference_id":       "team-ada",
+		"assigned_locations": []interface{}{location.ID},
+		"wage_setting": []interface{}{
+			map[string]interface{}{
+				"is_overtime_exempt": true,
+				"job_assignment": []interface{}{
+					map[string]interface{}{"job_title": "Barista", "pay_type": "HOURLY", "hourly_rate": 1850},
+					map[string]interface{}{"job_title": "Manager", "pay_type": "SALARY", "annual_rate": 5200000, "weekly_hours": 40, "currency": "CAD"},
+				},
+			},
+		},
+	}
+
+	state, diags := testApply(t, r, meta, nil, config)
+	if diags.HasError() {
+		t.Fatalf("error creating team member: %v", diags)
+	}
+
+	teamMember, err := meta.api.RetrieveTeamMember(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving team member: %v", err)
+	}
+
+	if teamMember.Status != "ACTIVE" || teamMember.AssignedLocations == nil || len(teamMember.AssignedLocations.LocationIDs) != 1 || teamMember.AssignedLocations.LocationIDs[0] != location.ID {
+		t.Fatalf("unexpected team member %+v", teamMember)
+	}
+
+	wageSetting, err := meta.api.RetrieveWageSetting(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving wage setting: %v", err)
+	}
+
+	if len(wageSetting.JobAssignments) != 2 || !wageSetting.IsOvertimeExempt {
+		t.Fatalf("unexpected wage setting %+v", wageSetting)
+	}
+
+	if rate := wageSetting.JobAssignments[0].HourlyRate; rate == nil || rate.Amount != 1850 || rate.Currency != "USD" {
+		t.Fatalf("expected hourly rate in the default currency, found %+v", rate)
+	}
+
+	if rate := wageSetting.JobAssignments[1].AnnualRate; rate == nil || rate.Amount != 5200000 || rate.Currency != "CAD" {
+		t.Fatalf("unexpected annual rate %+v", rate)
+	}
+
+	// Square works out the hourly rate of the salaried job, which mustn't show up as a diff.
+	if rate := wageSetting.JobAssignments[1].HourlyRate; rate == nil || rate.Amount != 2500 {
+		t.Fatalf("expected the salaried job to have a calculated hourly rate, found %+v", rate)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning team member: %v", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("unexpected diff after apply: %v", diff)
+	}
+
+	delete(config, "email_address")
+	delete(config, "assigned_locations")
+	delete(config, "wage_setting")
+	config["all_locations"] = true
+
+	state, diags = testApply(t, r, meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("error updating team member: %v", diags)
+	}
+
+	teamMember, err = meta.api.RetrieveTeamMember(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving team member: %v", err)
+	}
+
+	if teamMember.EmailAddress != "" || teamMember.AssignedLocations.AssignmentType != squareapi.TeamMemberAssignmentTypeAll {
+		t.Fatalf("unexpected updated team member %+v", teamMember)
+	}
+
+	wageSetting, err = meta.api.RetrieveWageSetting(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving wage setting: %v", err)
+	}
+
+	if len(wageSetting.JobAssignments) != 0 {
+		t.Fatalf("expected removing the wage setting to remove its jobs, found %+v", wageSetting.JobAssignments)
+	}
+
+	diags = r.DeleteContext(context.Background(), r.Data(state), meta)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected deleting a team member to warn that it's been deactivated, found %v", diags)
+	}
+
+	teamMember, err = meta.api.RetrieveTeamMember(context.Background(), state.ID)
+	if err != nil {
+		t.Fatalf("error retrieving team member: %v", err)
+	}
+
+	if teamMember.Status != "INACTIVE" {
+		t.Fatalf("expected deleted team member to be inactive, found %s", teamMember.Status)
+	}
+}
+
+func TestTeamMemberJobAssignmentCustomizeDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		job         map[string]interface{}
+		errContains string
+	}{
+		"hourly": {
+			job: map[string]interface{}{"pay_type": "HOURLY", "hourly_rate": 1500, "currency": "GBP"},
+		},
+		"salary": {
+			job: map[string]interface{}{"pay_type": "SALARY", "annual_rate": 4000000, "weekly_hours": 37},
+		},
+		"unpaid": {
+			job: map[string]interface{}{"pay_type": "NONE"},
+		},
+		"hourly without rate": {
+			job:         map[string]interface{}{"pay_type": "HOURLY"},
+			errContains: "requires hourly_rate",
+		},
+		"salary without hours": {
+			job:         map[string]interface{}{"pay_type": "SALARY", "annual_rate": 4000000},
+			errContains: "requires annual_rate and weekly_hours",
+		},
+		"hourly with annual rate": {
+			job:         map[string]interface{}{"pay_type": "HOURLY", "hourly_rate": 1500, "annual_rate": 4000000},
+			errContains: "without annual_rate",
+		},
+		"unpaid with currency": {
+			job:         map[string]interface{}{"pay_type": "NONE", "currency": "USD"},
+			errContains: "rates can't be set",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			test.job["job_title"] = "job"
+			config := map[string]interface{}{
+				"given_name":  "Ada",
+				"family_name": "Lovelace",
+				"wage_setting": []interface{}{
+					map[string]interface{}{"job_assignment": []interface{}{test.job}},
+				},
+			}
+
+			_, err := resourceTeamMember().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+
+			switch {
+			case test.errContains == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.errContains != "" && (err == nil || !strings.Contains(err.Error(), test.errContains)):
+				t.Fatalf("expected error containing %q, found %v", test.errContains, err)
+			}
+		})
+	}
+
+	diags := resourceTeamMember().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"given_name":         "Ada",
+		"family_name":        "Lovelace",
+		"assigned_locations": []interface{}{"location"},
+		"all_locations":      true,
+	}))
+	if !diags.HasError() {
+		t.Fatalf("expected assigned_locations and all_locations to conflict")
+	}
+}
@@ -2,46 +2,55 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"strings"
 
-	"github.com/Houndie/square-go"
-	"github.com/Houndie/square-go/catalog"
 	"github.com/Houndie/square-go/objects"
-	"github.com/gofrs/uuid"
+	"github.com/Houndie/terraform-provider-square/internal/squareapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-type ResourceToObject func(*schema.ResourceData) (*objects.CatalogObject, error)
+type ResourceToObject func(*schema.ResourceData, *providerMeta) (*squareapi.CatalogObject, error)
 
-type ObjectToResource func(*objects.CatalogObject, *schema.ResourceData) error
+type ObjectToResource func(*squareapi.CatalogObject, *schema.ResourceData, *providerMeta) error
 
 func resourceCatalogUpsert(resourceToObject ResourceToObject, objectToResource ObjectToResource) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-		client, ok := m.(*square.Client)
+		meta, ok := m.(*providerMeta)
 		if !ok {
 			return diag.Errorf("unable to create client from interface")
 		}
 
-		idempotencyKey, err := uuid.NewV4()
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("error creating idempotency key: %w", err))
-		}
-
-		object, err := resourceToObject(d)
+		object, err := resourceToObject(d, meta)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
-		res, err := client.Catalog.UpsertObject(ctx, &catalog.UpsertObjectRequest{
-			IdempotencyKey: idempotencyKey.String(),
-			Object:         object,
-		})
+		res, err := meta.catalog.upsert(ctx, object)
+
+		// Drop the object from the read cache even if the upsert failed, as it may have been written anyway.
+		meta.cache.invalidate(d.Id())
+
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("error making network call to upsert object: %w", err))
+			if !isVersionMismatch(err) || d.Id() == "" {
+				return diag.FromErr(fmt.Errorf("error making network call to upsert object: %w", err))
+			}
+
+			var diags diag.Diagnostics
+
+			res, diags = resolveCatalogVersionConflict(ctx, meta, d, object, objectToResource)
+			if diags.HasError() {
+				return diags
+			}
 		}
 
-		if err := objectToResource(res.CatalogObject, d); err != nil {
+		meta.cache.invalidate(res.ID)
+
+		if err := objectToResource(res, d, meta); err != nil {
 			return diag.FromErr(err)
 		}
 
@@ -51,19 +60,31 @@ func resourceCatalogUpsert(resourceToObject ResourceToObject, objectToResource O
 
 func resourceCatalogRead(objectToResource ObjectToResource) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-		client, ok := m.(*square.Client)
+		meta, ok := m.(*providerMeta)
 		if !ok {
 			return diag.Errorf("unable to create client from interface")
 		}
 
-		res, err := client.Catalog.RetrieveObject(ctx, &catalog.RetrieveObjectRequest{
-			ObjectID: d.Id(),
-		})
+		res, err := meta.cache.retrieve(ctx, d.Id())
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("error making network call to upsert object: %w", err))
+			if isNotFound(err) {
+				log.Printf("[WARN] catalog object %s not found, removing from state", d.Id())
+				d.SetId("")
+
+				return nil
+			}
+
+			return diag.FromErr(fmt.Errorf("error making network call to retrieve object: %w", err))
+		}
+
+		if res == nil || res.IsDeleted {
+			log.Printf("[WARN] catalog object %s has been deleted, removing from state", d.Id())
+			d.SetId("")
+
+			return nil
 		}
 
-		if err := objectToResource(res.Object, d); err != nil {
+		if err := objectToResource(res, d, meta); err != nil {
 			return diag.FromErr(err)
 		}
 
@@ -71,18 +92,46 @@ func resourceCatalogRead(objectToResource ObjectToResource) func(context.Context
 	}
 }
 
+func resourceCatalogImport(objectToResource ObjectToResource) func(context.Context, *schema.ResourceData, interface{}) ([]*schema.ResourceData, error) {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		meta, ok := m.(*providerMeta)
+		if !ok {
+			return nil, fmt.Errorf("unable to create client from interface")
+		}
+
+		res, err := meta.api.RetrieveCatalogObject(ctx, d.Id())
+		if err != nil {
+			if isNotFound(err) {
+				return nil, fmt.Errorf("catalog object %s not found", d.Id())
+			}
+
+			return nil, fmt.Errorf("error making network call to retrieve object: %w", err)
+		}
+
+		if res == nil || res.IsDeleted {
+			return nil, fmt.Errorf("catalog object %s not found, it has been deleted", d.Id())
+		}
+
+		if err := objectToResource(res, d, meta); err != nil {
+			return nil, fmt.Errorf("error importing object %s: %w", d.Id(), err)
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
 func resourceCatalogDelete() func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-		client, ok := m.(*square.Client)
+		meta, ok := m.(*providerMeta)
 		if !ok {
 			return diag.Errorf("unable to create client from interface")
 		}
 
-		_, err := client.Catalog.DeleteObject(ctx, &catalog.DeleteObjectRequest{
-			ObjectID: d.Id(),
-		})
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("error making network call to upsert object: %w", err))
+		err := meta.catalog.delete(ctx, d.Id())
+		meta.cache.invalidate(d.Id())
+
+		if err != nil && !isNotFound(err) {
+			return diag.FromErr(fmt.Errorf("error making network call to delete object: %w", err))
 		}
 
 		d.SetId("")
@@ -90,3 +139,208 @@ func resourceCatalogDelete() func(context.Context, *schema.ResourceData, interfa
 		return nil
 	}
 }
+
+// dataSourceCatalogSchema builds a data source schema out of a catalog resource's schema.  Every attribute
+// becomes computed, except for id and name, which are used to look up the object.
+func dataSourceCatalogSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	dataSchema := computedSchemaMap(resourceSchema)
+
+	dataSchema["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+
+	dataSchema["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+
+	return dataSchema
+}
+
+func computedSchemaMap(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	computed := make(map[string]*schema.Schema, len(resourceSchema))
+
+	for k, v := range resourceSchema {
+		computed[k] = computedSchema(v)
+	}
+
+	return computed
+}
+
+func computedSchema(s *schema.Schema) *schema.Schema {
+	c := &schema.Schema{
+		Type:     s.Type,
+		Computed: true,
+		Set:      s.Set,
+	}
+
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		c.Elem = &schema.Resource{Schema: computedSchemaMap(elem.Schema)}
+
+		// Hash with the resource's element schema, as the computed copy would otherwise skip every field.
+		if s.Type == schema.TypeSet && c.Set == nil {
+			c.Set = schema.HashResource(elem)
+		}
+	case *schema.Schema:
+		c.Elem = &schema.Schema{Type: elem.Type}
+	}
+
+	return c
+}
+
+func dataSourceCatalogRead(objectType objects.CatalogObjectEnumType, objectToResource ObjectToResource) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		meta, ok := m.(*providerMeta)
+		if !ok {
+			return diag.Errorf("unable to create client from interface")
+		}
+
+		var object *squareapi.CatalogObject
+
+		if id := d.Get("id").(string); id != "" {
+			var err error
+
+			object, err = meta.api.RetrieveCatalogObject(ctx, id)
+			if err != nil {
+				if isNotFound(err) {
+					return diag.Errorf("no catalog object found with id %s", id)
+				}
+
+				return diag.FromErr(fmt.Errorf("error making network call to retrieve object: %w", err))
+			}
+
+			if object == nil || object.IsDeleted {
+				return diag.Errorf("no catalog object found with id %s", id)
+			}
+		} else {
+			var err error
+
+			object, err = findCatalogObjectByName(ctx, meta, objectType, d.Get("name").(string))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		if foundType, _ := catalogObjectTypeAndName(object.CatalogObject); objectType != "" && foundType != objectType {
+			return diag.Errorf("catalog object %s is of type %s, expected %s", object.ID, foundType, objectType)
+		}
+
+		if err := objectToResource(object, d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+}
+
+// findCatalogObjectByName looks up the single catalog object with the given name.  Square's exact query
+// ignores case, so the results are filtered again here to match the name exactly.
+func findCatalogObjectByName(ctx context.Context, meta *providerMeta, objectType objects.CatalogObjectEnumType, name string) (*squareapi.CatalogObject, error) {
+	types := []objects.CatalogObjectEnumType{}
+	if objectType != "" {
+		types = append(types, objectType)
+	}
+
+	found, err := meta.api.SearchCatalogObjectsExact(ctx, types, "name", name)
+	if err != nil {
+		return nil, fmt.Errorf("error making network call to search objects: %w", err)
+	}
+
+	matches := []*squareapi.CatalogObject{}
+
+	for _, o := range found {
+		if o.IsDeleted {
+			continue
+		}
+
+		if _, oName := catalogObjectTypeAndName(o.CatalogObject); oName == name {
+			matches = append(matches, o)
+		}
+	}
+
+	description := "catalog object"
+	if objectType != "" {
+		description = fmt.Sprintf("catalog object of type %s", objectType)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no %s found with name %q", description, name)
+	case 1:
+		return matches[0], nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, match := range matches {
+		ids[i] = match.ID
+	}
+
+	return nil, fmt.Errorf("found %d of %s with name %q, use the id to pick one: %s", len(matches), description, name, strings.Join(ids, ", "))
+}
+
+// catalogObjectTypeAndName returns the type of a catalog object along with its name, if that type of object
+// has one.
+func catalogObjectTypeAndName(o *objects.CatalogObject) (objects.CatalogObjectEnumType, string) {
+	switch t := o.Type.(type) {
+	case *objects.CatalogItem:
+		return objects.CatalogObjectEnumTypeItem, t.Name
+	case *objects.CatalogItemVariation:
+		return objects.CatalogObjectEnumTypeItemVariation, t.Name
+	case *objects.CatalogCategory:
+		return objects.CatalogObjectEnumTypeCategory, t.Name
+	case *objects.CatalogTax:
+		return objects.CatalogObjectEnumTypeTax, t.Name
+	case *objects.CatalogDiscount:
+		return objects.CatalogObjectEnumTypeDiscount, t.Name
+	case *objects.CatalogModifierList:
+		return objects.CatalogObjectEnumTypeModifierList, t.Name
+	case *objects.CatalogModifier:
+		return objects.CatalogObjectEnumTypeModifier, t.Name
+	case *objects.CatalogImage:
+		return objects.CatalogObjectEnumTypeImage, t.Name
+	case *objects.CatalogPricingRule:
+		return objects.CatalogObjectEnumTypePricingRule, t.Name
+	case *objects.CatalogProductSet:
+		return objects.CatalogObjectEnumTypeProductSet, t.Name
+	case *objects.CatalogTimePeriod:
+		return objects.CatalogObjectEnumTypeTimePeriod, ""
+	case *objects.CatalogMeasurementUnit:
+		return objects.CatalogObjectEnumTypeMeasurementUnit, ""
+	case *objects.CatalogSubscriptionPlan:
+		return objects.CatalogObjectEnumTypeSubscriptionPlan, t.Name
+	case *objects.CatalogItemOption:
+		return objects.CatalogObjectEnumTypeItemOption, t.Name
+	case *objects.CatalogItemOptionValue:
+		return objects.CatalogObjectEnumTypeItemOptionVal, t.Name
+	case *objects.CatalogCustomAttributeDefinition:
+		return objects.CatalogObjectEnumTypeCustomAttributeDefinition, t.Name
+	case *objects.CatalogQuickAmountsSettings:
+		return objects.CatalogObjectEnumTypeQuickAmountsSettings, ""
+	}
+
+	return "", ""
+}
+
+// isNotFound reports whether err was caused by Square being unable to find the requested object.
+func isNotFound(err error) bool {
+	var errList *objects.ErrorList
+	if errors.As(err, &errList) {
+		for _, e := range errList.Errors {
+			if e.Code == objects.ErrorCodeNotFound {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	var codeErr objects.UnexpectedCodeError
+
+	return errors.As(err, &codeErr) && int(codeErr) == http.StatusNotFound
+}
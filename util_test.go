@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Houndie/square-go/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name: "not found error",
+			err: fmt.Errorf("wrapped: %w", &objects.ErrorList{Errors: []*objects.Error{{
+				Category: objects.ErrorCategoryInvalidRequestError,
+				Code:     objects.ErrorCodeNotFound,
+			}}}),
+			expected: true,
+		},
+		{
+			name: "other square error",
+			err: fmt.Errorf("wrapped: %w", &objects.ErrorList{Errors: []*objects.Error{{
+				Category: objects.ErrorCategoryInvalidRequestError,
+				Code:     objects.ErrorCodeBadRequest,
+			}}}),
+			expected: false,
+		},
+		{
+			name:     "404 status code",
+			err:      fmt.Errorf("wrapped: %w", objects.UnexpectedCodeError(http.StatusNotFound)),
+			expected: true,
+		},
+		{
+			name:     "500 status code",
+			err:      fmt.Errorf("wrapped: %w", objects.UnexpectedCodeError(http.StatusInternalServerError)),
+			expected: false,
+		},
+		{
+			name:     "unrelated error",
+			err:      errors.New("connection refused"),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if isNotFound(test.err) != test.expected {
+				t.Fatalf("expected isNotFound to return %v", test.expected)
+			}
+		})
+	}
+}
+
+func TestResourceCatalogImportNotFound(t *testing.T) {
+	t.Parallel()
+
+	meta := testProviderMeta(t, nil)
+	r := resourceCatalogCategory()
+
+	state, diags := testApply(t, r, meta, nil, map[string]interface{}{"name": "import-deleted-category"})
+	if diags.HasError() {
+		t.Fatalf("error creating category: %v", diags)
+	}
+
+	if _, err := meta.api.DeleteCatalogObject(context.Background(), state.ID); err != nil {
+		t.Fatalf("error deleting category: %v", err)
+	}
+
+	for _, id := range []string{state.ID, "missing-category"} {
+		_, err := r.Importer.StateContext(context.Background(), r.Data(&terraform.InstanceState{ID: id}), meta)
+		if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("catalog object %s not found", id)) {
+			t.Fatalf("expected not found error importing %s, found %v", id, err)
+		}
+	}
+}